@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/joho/godotenv"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2"
 
+	"gafarov/rss-reader/internal/model/feed"
 	"gafarov/rss-reader/internal/pkg/app"
 )
 
@@ -88,6 +92,20 @@ func initLogger() *zap.Logger {
 	return logger
 }
 
+// parseMapping разбирает строку вида "{ns}lead=description,rating=region".
+// Значения со знаком "=" не поддерживаются
+func parseMapping(s string) map[string]string {
+	mapping := make(map[string]string)
+	for pair := range strings.SplitSeq(s, ",") {
+		i := strings.LastIndex(pair, "=")
+		if i <= 0 {
+			continue
+		}
+		mapping[strings.TrimSpace(pair[:i])] = strings.TrimSpace(pair[i+1:])
+	}
+	return mapping
+}
+
 func main() {
 
 	logger := initLogger()
@@ -126,11 +144,48 @@ func main() {
 	}
 
 	kafkaData := app.KafkaData{
-		Addr:  []string{kafka_addr},
-		Topic: kafka_topic,
+		Addr:       []string{kafka_addr},
+		Topic:      kafka_topic,
+		Tombstones: strings.ToLower(os.Getenv("KAFKA_TOMBSTONES")) == "true",
+	}
+
+	readerData := app.ReaderData{
+		// строгий разбор по умолчанию, как в reader.New: RSS_STRICT=false включает нестрогий
+		Strict:  strings.ToLower(os.Getenv("RSS_STRICT")) != "false",
+		Promote: parseMapping(os.Getenv("RSS_EXTRA_FIELDS")),
+		// RSS_RECORD_DIR записывает ответы лент, RSS_REPLAY_DIR воспроизводит их без сети
+		RecordDir: os.Getenv("RSS_RECORD_DIR"),
+		ReplayDir: os.Getenv("RSS_REPLAY_DIR"),
+		// RSS_SOURCES: file,dir,stdin - ленты из файлов, каталога выгрузки и стандартного ввода
+		Sources: strings.FieldsFunc(os.Getenv("RSS_SOURCES"), func(r rune) bool { return r == ',' || r == ' ' }),
+	}
+
+	if value := os.Getenv("RSS_HOST_CONCURRENCY"); value != "" {
+		concurrency, err := strconv.Atoi(value)
+		if err != nil {
+			logger.Fatal("Invalid RSS_HOST_CONCURRENCY", zap.String("value", value), zap.Error(err))
+			os.Exit(1)
+		}
+		readerData.Limits.PerHostConcurrency = concurrency
+	}
+	if value := os.Getenv("RSS_HOST_RATE"); value != "" {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			logger.Fatal("Invalid RSS_HOST_RATE", zap.String("value", value), zap.Error(err))
+			os.Exit(1)
+		}
+		readerData.Limits.PerHostRate = rate
+	}
+	if value := os.Getenv("RSS_MAX_CONCURRENT_FETCHES"); value != "" {
+		concurrency, err := strconv.Atoi(value)
+		if err != nil {
+			logger.Fatal("Invalid RSS_MAX_CONCURRENT_FETCHES", zap.String("value", value), zap.Error(err))
+			os.Exit(1)
+		}
+		readerData.Limits.GlobalConcurrency = concurrency
 	}
 
-	app, err := app.New(redisData, kafkaData, logger)
+	app, err := app.New(redisData, kafkaData, readerData, logger)
 	if err != nil {
 		logger.Fatal("Failed to create application", zap.Error(err))
 		os.Exit(1)
@@ -148,6 +203,65 @@ func main() {
 		os.Exit(1)
 	}
 
+	feedConfig := feed.Config{
+		Identity: feed.IdentityStrategy(os.Getenv("RSS_IDENTITY")),
+		// для file://, dir:// и stdin:// отправляются и элементы первого чтения
+		EmitExisting: strings.ToLower(os.Getenv("RSS_EMIT_EXISTING")) == "true",
+	}
+	if timezone := os.Getenv("RSS_TIMEZONE"); timezone != "" {
+		location, err := time.LoadLocation(timezone)
+		if err != nil {
+			logger.Fatal("Invalid RSS_TIMEZONE", zap.String("timezone", timezone), zap.Error(err))
+			os.Exit(1)
+		}
+		feedConfig.Location = location
+	}
+	if attempts := os.Getenv("RSS_RETRY_ATTEMPTS"); attempts != "" {
+		value, err := strconv.Atoi(attempts)
+		if err != nil {
+			logger.Fatal("Invalid RSS_RETRY_ATTEMPTS", zap.String("attempts", attempts), zap.Error(err))
+			os.Exit(1)
+		}
+		feedConfig.Retry.Attempts = value
+	}
+	if interval := os.Getenv("RSS_MAX_POLL_INTERVAL"); interval != "" {
+		value, err := time.ParseDuration(interval)
+		if err != nil {
+			logger.Fatal("Invalid RSS_MAX_POLL_INTERVAL", zap.String("interval", interval), zap.Error(err))
+			os.Exit(1)
+		}
+		feedConfig.MaxPollInterval = value
+	}
+	feedConfig.HTTP = feed.HTTPConfig{
+		UserAgent:   os.Getenv("RSS_USER_AGENT"),
+		Headers:     feed.ParsePairs(os.Getenv("RSS_HEADERS")),
+		BearerToken: os.Getenv("RSS_BEARER_TOKEN"),
+		Query:       feed.ParsePairs(os.Getenv("RSS_QUERY")),
+		Cookies:     feed.ParsePairs(os.Getenv("RSS_COOKIES")),
+		Transport: feed.TransportConfig{
+			ProxyURL:      os.Getenv("RSS_PROXY_URL"),
+			CAFile:        os.Getenv("RSS_CA_FILE"),
+			CertFile:      os.Getenv("RSS_CERT_FILE"),
+			KeyFile:       os.Getenv("RSS_KEY_FILE"),
+			MinTLSVersion: os.Getenv("RSS_MIN_TLS_VERSION"),
+		},
+	}
+	if username := os.Getenv("RSS_BASIC_USER"); username != "" {
+		feedConfig.HTTP.BasicAuth = &feed.BasicAuth{
+			Username: username,
+			Password: os.Getenv("RSS_BASIC_PASSWORD"),
+		}
+	}
+	if tokenURL := os.Getenv("RSS_OAUTH_TOKEN_URL"); tokenURL != "" {
+		feedConfig.HTTP.OAuth2 = &feed.OAuth2Config{
+			TokenURL:     tokenURL,
+			ClientID:     os.Getenv("RSS_OAUTH_CLIENT_ID"),
+			ClientSecret: os.Getenv("RSS_OAUTH_CLIENT_SECRET"),
+			Scopes:       strings.Fields(os.Getenv("RSS_OAUTH_SCOPES")),
+		}
+	}
+	app.ConfigureFeed(rss_url, feedConfig)
+
 	delay := 5 * time.Second
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
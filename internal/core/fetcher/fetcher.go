@@ -0,0 +1,26 @@
+package fetcher
+
+import (
+	"context"
+	"net/http"
+)
+
+// IFetcher получает документ ленты. Источники, отличные от HTTP, имитируют ответ сервера:
+// 200 с телом документа, 304 если документ не изменился с прошлого чтения
+// (If-None-Match или If-Modified-Since), 404 если документа нет
+type IFetcher interface {
+	Fetch(req *http.Request) (*http.Response, error)
+}
+
+type queryKeysKey struct{}
+
+// WithQueryKeys передает источнику имена параметров, добавленных к адресу из настроек ленты.
+// Их значения - секреты, которые нельзя сохранять на диск
+func WithQueryKeys(ctx context.Context, keys []string) context.Context {
+	return context.WithValue(ctx, queryKeysKey{}, keys)
+}
+
+func QueryKeys(ctx context.Context) []string {
+	keys, _ := ctx.Value(queryKeysKey{}).([]string)
+	return keys
+}
@@ -0,0 +1,80 @@
+package implementation
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Расширения файлов, которые забираются из каталога
+var documentExtensions = []string{".xml", ".rss", ".atom", ".rdf", ".json"}
+
+// Directory читает ленты, которые выгружают в каталог внешние задания: dir:///var/feeds/inbox.
+// Файлы отдаются по одному в лексикографическом порядке имен. ETag ответа - имя файла,
+// поэтому следующий запрос с If-None-Match получает следующий файл, а при отсутствии
+// новых файлов - 304. Файлы не удаляются и не перемещаются
+type Directory struct{}
+
+func NewDirectory() *Directory {
+	return &Directory{}
+}
+
+func (d *Directory) Fetch(req *http.Request) (*http.Response, error) {
+	dir := req.URL.Path
+	if dir == "" {
+		dir = req.URL.Opaque
+	}
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return newResponse(req, http.StatusNotFound, nil, nil, 0), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && slices.Contains(documentExtensions, strings.ToLower(filepath.Ext(entry.Name()))) {
+			names = append(names, entry.Name())
+		}
+	}
+	slices.Sort(names)
+
+	last := ""
+	if match := req.Header.Get("If-None-Match"); match != "" {
+		if unquoted, err := strconv.Unquote(match); err == nil {
+			last = unquoted
+		}
+	}
+
+	for _, name := range names {
+		if name <= last {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		file, err := os.Open(path)
+		if err != nil {
+			// файл могли удалить между чтением каталога и открытием
+			continue
+		}
+		info, err := file.Stat()
+		if err != nil {
+			file.Close()
+			continue
+		}
+
+		header := http.Header{
+			"Etag":         {strconv.Quote(name)},
+			"Content-Type": {contentType(path)},
+		}
+		return newResponse(req, http.StatusOK, header, file, info.Size()), nil
+	}
+
+	return newResponse(req, http.StatusNotModified, nil, nil, 0), nil
+}
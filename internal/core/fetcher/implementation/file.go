@@ -0,0 +1,53 @@
+package implementation
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+)
+
+// File читает ленту из файла: file:///var/feeds/partner.xml
+type File struct{}
+
+func NewFile() *File {
+	return &File{}
+}
+
+func (f *File) Fetch(req *http.Request) (*http.Response, error) {
+	path := req.URL.Path
+	if path == "" {
+		path = req.URL.Opaque
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return newResponse(req, http.StatusNotFound, nil, nil, 0), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if info.IsDir() {
+		file.Close()
+		return nil, fmt.Errorf("%s is a directory", path)
+	}
+
+	etag := fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size())
+	header := http.Header{
+		"Etag":          {etag},
+		"Last-Modified": {info.ModTime().UTC().Format(http.TimeFormat)},
+		"Content-Type":  {contentType(path)},
+	}
+
+	if notModified(req, etag, info.ModTime()) {
+		file.Close()
+		return newResponse(req, http.StatusNotModified, header, nil, 0), nil
+	}
+	return newResponse(req, http.StatusOK, header, file, info.Size()), nil
+}
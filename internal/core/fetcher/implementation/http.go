@@ -0,0 +1,19 @@
+package implementation
+
+import "net/http"
+
+// HTTP получает ленту обычным HTTP-клиентом
+type HTTP struct {
+	client *http.Client
+}
+
+func NewHTTP(client *http.Client) *HTTP {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTP{client: client}
+}
+
+func (f *HTTP) Fetch(req *http.Request) (*http.Response, error) {
+	return f.client.Do(req)
+}
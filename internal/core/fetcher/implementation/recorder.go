@@ -0,0 +1,162 @@
+package implementation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gafarov/rss-reader/internal/core/fetcher"
+	"gafarov/rss-reader/internal/model/feed"
+	model "gafarov/rss-reader/internal/model/fetcher"
+
+	"go.uber.org/zap"
+)
+
+// secretHeaders не сохраняются: в них сессии и токены
+var secretHeaders = []string{"Set-Cookie", "Set-Cookie2", "Authorization", "Proxy-Authorization"}
+
+// recordURL - адрес без секретов, в том числе параметров из настроек ленты
+func recordURL(req *http.Request) string {
+	return feed.RedactURL(req.URL.String(), fetcher.QueryKeys(req.Context())...)
+}
+
+// Recorder сохраняет ответы источников в каталог: NNNNNN.json с заголовками и временем
+// и NNNNNN.body с телом. Нумерация общая для всех лент и продолжается при повторном запуске,
+// поэтому Replay воспроизводит опросы в исходном порядке
+type Recorder struct {
+	dir    string
+	mu     sync.Mutex
+	seq    int
+	logger *zap.Logger
+}
+
+func NewRecorder(dir string, logger *zap.Logger) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	names, err := recordNames(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	seq := 0
+	if len(names) > 0 {
+		seq, _ = strconv.Atoi(strings.TrimSuffix(names[len(names)-1], ".json"))
+	}
+	return &Recorder{dir: dir, seq: seq, logger: logger}, nil
+}
+
+// Wrap возвращает источник, который записывает ответы next
+func (r *Recorder) Wrap(next fetcher.IFetcher) fetcher.IFetcher {
+	return &recording{recorder: r, next: next}
+}
+
+type recording struct {
+	recorder *Recorder
+	next     fetcher.IFetcher
+}
+
+func (f *recording) Fetch(req *http.Request) (*http.Response, error) {
+	response, err := f.next.Fetch(req)
+	record := model.Record{URL: recordURL(req), Time: time.Now()}
+
+	if err != nil {
+		record.Error = err.Error()
+		var netErr net.Error
+		record.Timeout = errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout()
+		f.recorder.save(record, nil)
+		return nil, err
+	}
+
+	record.StatusCode = response.StatusCode
+	record.Header = response.Header.Clone()
+	for _, name := range secretHeaders {
+		record.Header.Del(name)
+	}
+	// тело записывается по мере чтения: сохраняется ровно то, что прочитал читатель,
+	// а ограничения размера продолжают работать
+	response.Body = &recordingBody{ReadCloser: response.Body, recorder: f.recorder, record: record}
+	return response, nil
+}
+
+type recordingBody struct {
+	io.ReadCloser
+	recorder *Recorder
+	record   model.Record
+	buf      bytes.Buffer
+	once     sync.Once
+}
+
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.buf.Write(p[:n])
+	return n, err
+}
+
+func (b *recordingBody) Close() error {
+	b.once.Do(func() {
+		b.recorder.save(b.record, b.buf.Bytes())
+	})
+	return b.ReadCloser.Close()
+}
+
+func (r *Recorder) save(record model.Record, body []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.write(record, body); err != nil && r.logger != nil {
+		r.logger.Error("failed to record response", zap.String("url", record.URL), zap.Error(err))
+	}
+}
+
+func (r *Recorder) write(record model.Record, body []byte) error {
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	r.seq++
+	name := filepath.Join(r.dir, fmt.Sprintf("%06d", r.seq))
+	// тело пишется первым: запись без .json при воспроизведении не видна
+	if err := os.WriteFile(name+".body", body, 0o644); err != nil {
+		return err
+	}
+	return writeFileAtomic(name+".json", data)
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// recordNames возвращает имена файлов записей по порядку
+func recordNames(dir string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "[0-9]*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(matches))
+	for _, match := range matches {
+		names = append(names, filepath.Base(match))
+	}
+	// имена одной длины, поэтому лексикографический порядок совпадает с числовым
+	slices.Sort(names)
+	return names, nil
+}
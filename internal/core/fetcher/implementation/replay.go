@@ -0,0 +1,106 @@
+package implementation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	model "gafarov/rss-reader/internal/model/fetcher"
+)
+
+// ErrReplayExhausted - записанные ответы для адреса закончились
+var ErrReplayExhausted = errors.New("no more recorded responses")
+
+// Replay отдает ответы, записанные Recorder, по порядку для каждого адреса.
+// Условные заголовки не учитываются: воспроизводится ровно то, что ответил источник
+type Replay struct {
+	mu      sync.Mutex
+	records map[string][]replayRecord
+}
+
+type replayRecord struct {
+	record model.Record
+	body   []byte
+}
+
+func NewReplay(dir string) (*Replay, error) {
+	names, err := recordNames(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make(map[string][]replayRecord)
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		var record model.Record
+		if err := json.Unmarshal(data, &record); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		body, err := os.ReadFile(filepath.Join(dir, strings.TrimSuffix(name, ".json")+".body"))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+
+		records[record.URL] = append(records[record.URL], replayRecord{record: record, body: body})
+	}
+
+	return &Replay{records: records}, nil
+}
+
+func (r *Replay) Fetch(req *http.Request) (*http.Response, error) {
+	url := recordURL(req)
+
+	r.mu.Lock()
+	queue := r.records[url]
+	if len(queue) == 0 {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrReplayExhausted, url)
+	}
+	next := queue[0]
+	r.records[url] = queue[1:]
+	r.mu.Unlock()
+
+	if next.record.Error != "" {
+		return nil, &replayError{message: next.record.Error, timeout: next.record.Timeout}
+	}
+
+	header := next.record.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return newResponse(req, next.record.StatusCode, header, io.NopCloser(bytes.NewReader(next.body)), int64(len(next.body))), nil
+}
+
+// replayError воспроизводит записанную ошибку, в том числе признак таймаута net.Error
+type replayError struct {
+	message string
+	timeout bool
+}
+
+func (e *replayError) Error() string   { return e.message }
+func (e *replayError) Timeout() bool   { return e.timeout }
+func (e *replayError) Temporary() bool { return e.timeout }
+
+// Remaining - число еще не воспроизведенных ответов
+func (r *Replay) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for _, queue := range r.records {
+		count += len(queue)
+	}
+	return count
+}
@@ -0,0 +1,64 @@
+package implementation
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func newResponse(req *http.Request, status int, header http.Header, body io.ReadCloser, length int64) *http.Response {
+	if header == nil {
+		header = make(http.Header)
+	}
+	if body == nil {
+		body = io.NopCloser(bytes.NewReader(nil))
+	}
+	if length >= 0 {
+		header.Set("Content-Length", strconv.FormatInt(length, 10))
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(status) + " " + http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          body,
+		ContentLength: length,
+		Request:       req,
+	}
+}
+
+// contentType определяет тип по расширению без charset: кодировку задает пролог XML
+func contentType(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".rss":
+		return "application/rss+xml"
+	case ".atom":
+		return "application/atom+xml"
+	case ".rdf":
+		return "application/rdf+xml"
+	case ".json":
+		return "application/json"
+	}
+	if mediaType, _, err := mime.ParseMediaType(mime.TypeByExtension(filepath.Ext(path))); err == nil {
+		return mediaType
+	}
+	return "application/xml"
+}
+
+// notModified проверяет условные заголовки запроса
+func notModified(req *http.Request, etag string, modified time.Time) bool {
+	if match := req.Header.Get("If-None-Match"); match != "" {
+		return match == etag
+	}
+	if since, err := http.ParseTime(req.Header.Get("If-Modified-Since")); err == nil && !modified.IsZero() {
+		return !modified.Truncate(time.Second).After(since)
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package implementation
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultMaxStdinSize ограничивает документ, который держится в памяти
+const DefaultMaxStdinSize = 20 << 20
+
+var ErrStdinTooLarge = errors.New("stdin document is too large")
+
+// Stdin читает одну ленту из стандартного ввода: stdin://.
+// Документ читается при первом запросе и затем отдается из памяти
+type Stdin struct {
+	mu      sync.Mutex
+	reader  io.Reader
+	maxSize int64
+	data    []byte
+	etag    string
+	err     error
+	read    bool
+}
+
+// NewStdin - maxSize 0 означает DefaultMaxStdinSize
+func NewStdin(reader io.Reader, maxSize int64) *Stdin {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxStdinSize
+	}
+	return &Stdin{reader: reader, maxSize: maxSize}
+}
+
+func (s *Stdin) Fetch(req *http.Request) (*http.Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.read {
+		s.read = true
+		s.data, s.err = io.ReadAll(io.LimitReader(s.reader, s.maxSize+1))
+		if s.err == nil && int64(len(s.data)) > s.maxSize {
+			s.data, s.err = nil, fmt.Errorf("%w: limit %d bytes", ErrStdinTooLarge, s.maxSize)
+		}
+		s.etag = fmt.Sprintf(`"%x"`, sha1.Sum(s.data))
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	header := http.Header{"Etag": {s.etag}}
+	if notModified(req, s.etag, time.Time{}) {
+		return newResponse(req, http.StatusNotModified, header, nil, 0), nil
+	}
+	return newResponse(req, http.StatusOK, header, io.NopCloser(bytes.NewReader(s.data)), int64(len(s.data))), nil
+}
@@ -0,0 +1,98 @@
+package implementation_test
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gafarov/rss-reader/internal/core/fetcher"
+	"gafarov/rss-reader/internal/core/fetcher/implementation"
+)
+
+func fetch(t *testing.T, f fetcher.IFetcher, url, etag string) (*http.Response, string) {
+	req, err := http.NewRequest("GET", url, nil)
+	require.NoError(t, err)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	response, err := f.Fetch(req)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	return response, string(data)
+}
+
+func TestFile_Fetch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feed.rss")
+	require.NoError(t, os.WriteFile(path, []byte("<rss/>"), 0o644))
+
+	f := implementation.NewFile()
+	response, body := fetch(t, f, "file://"+path, "")
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "<rss/>", body)
+	assert.Equal(t, "application/rss+xml", response.Header.Get("Content-Type"))
+	assert.NotEmpty(t, response.Header.Get("Last-Modified"))
+
+	response, _ = fetch(t, f, "file://"+path, response.Header.Get("ETag"))
+	assert.Equal(t, http.StatusNotModified, response.StatusCode)
+
+	response, _ = fetch(t, f, "file://"+path+".missing", "")
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
+
+func TestDirectory_Fetch(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "002.xml"), []byte("second"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "001.xml"), []byte("first"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("skip"), 0o644))
+
+	f := implementation.NewDirectory()
+	response, body := fetch(t, f, "dir://"+dir, "")
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "first", body)
+
+	response, body = fetch(t, f, "dir://"+dir, response.Header.Get("ETag"))
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "second", body)
+
+	etag := response.Header.Get("ETag")
+	response, _ = fetch(t, f, "dir://"+dir, etag)
+	assert.Equal(t, http.StatusNotModified, response.StatusCode)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "003.xml"), []byte("third"), 0o644))
+	_, body = fetch(t, f, "dir://"+dir, etag)
+	assert.Equal(t, "third", body)
+}
+
+func TestStdin_Fetch(t *testing.T) {
+	f := implementation.NewStdin(strings.NewReader("<rss/>"), 0)
+
+	response, body := fetch(t, f, "stdin://", "")
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "<rss/>", body)
+
+	// повторный безусловный запрос получает тот же документ из памяти
+	_, body = fetch(t, f, "stdin://", "")
+	assert.Equal(t, "<rss/>", body)
+
+	response, _ = fetch(t, f, "stdin://", response.Header.Get("ETag"))
+	assert.Equal(t, http.StatusNotModified, response.StatusCode)
+}
+
+func TestStdin_MaxSize(t *testing.T) {
+	f := implementation.NewStdin(strings.NewReader(strings.Repeat("x", 11)), 10)
+
+	req, err := http.NewRequest("GET", "stdin://", nil)
+	require.NoError(t, err)
+	_, err = f.Fetch(req)
+	assert.ErrorIs(t, err, implementation.ErrStdinTooLarge)
+}
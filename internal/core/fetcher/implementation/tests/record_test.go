@@ -0,0 +1,108 @@
+package implementation_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gafarov/rss-reader/internal/core/fetcher/implementation"
+)
+
+type fetcherFunc func(req *http.Request) (*http.Response, error)
+
+func (f fetcherFunc) Fetch(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRecorder_Replay(t *testing.T) {
+	responses := []string{"first", "second", "other"}
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("ETag", `"`+responses[requests]+`"`)
+		_, _ = w.Write([]byte(responses[requests]))
+		requests++
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	recorder, err := implementation.NewRecorder(dir, nil)
+	require.NoError(t, err)
+
+	source := recorder.Wrap(implementation.NewHTTP(server.Client()))
+	url := server.URL + "/feed.xml?token=secret"
+	_, body := fetch(t, source, url, "")
+	assert.Equal(t, "first", body)
+	_, body = fetch(t, source, url, "")
+	assert.Equal(t, "second", body)
+
+	failing := recorder.Wrap(fetcherFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, &net.OpError{Op: "dial", Err: context.DeadlineExceeded}
+	}))
+	req, err := http.NewRequest("GET", url, nil)
+	require.NoError(t, err)
+	_, err = failing.Fetch(req)
+	require.Error(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, "000001.json"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret")
+
+	// запись продолжает нумерацию существующего каталога
+	recorder, err = implementation.NewRecorder(dir, nil)
+	require.NoError(t, err)
+	_, _ = fetch(t, recorder.Wrap(implementation.NewHTTP(server.Client())), server.URL+"/other.xml", "")
+	assert.FileExists(t, filepath.Join(dir, "000004.json"))
+
+	replay, err := implementation.NewReplay(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 4, replay.Remaining())
+
+	response, body := fetch(t, replay, url, "")
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "first", body)
+	assert.Equal(t, `"first"`, response.Header.Get("ETag"))
+
+	_, body = fetch(t, replay, url, "")
+	assert.Equal(t, "second", body)
+
+	_, err = replay.Fetch(req)
+	var netErr net.Error
+	require.ErrorAs(t, err, &netErr)
+	assert.True(t, netErr.Timeout())
+
+	_, err = replay.Fetch(req)
+	assert.ErrorIs(t, err, implementation.ErrReplayExhausted)
+	assert.Equal(t, 1, replay.Remaining())
+	assert.Equal(t, 3, requests)
+}
+
+func TestRecorder_FetchError(t *testing.T) {
+	dir := t.TempDir()
+	recorder, err := implementation.NewRecorder(dir, nil)
+	require.NoError(t, err)
+
+	source := recorder.Wrap(implementation.NewStdin(errReader{}, 0))
+	req, err := http.NewRequest("GET", "stdin://", nil)
+	require.NoError(t, err)
+	_, err = source.Fetch(req)
+	assert.Error(t, err)
+
+	replay, err := implementation.NewReplay(dir)
+	require.NoError(t, err)
+	_, err = replay.Fetch(req)
+	assert.EqualError(t, err, "read failed")
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("read failed")
+}
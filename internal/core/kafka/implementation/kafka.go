@@ -15,9 +15,10 @@ import (
 )
 
 type Kafka struct {
-	writer   *kafka.Writer
-	logger   *zap.Logger
-	stopOnce sync.Once
+	writer     *kafka.Writer
+	logger     *zap.Logger
+	stopOnce   sync.Once
+	tombstones bool
 }
 
 func ping(addr ...string) error {
@@ -49,10 +50,11 @@ func New(logger *zap.Logger, topic string, addr ...string) (*Kafka, error) {
 		return nil, err
 	}
 
+	// Hash по ключу: сообщения одного элемента, включая tombstone, попадают в одну партицию
 	writer := kafka.NewWriter(kafka.WriterConfig{
 		Brokers:      addr,
 		Topic:        topic,
-		Balancer:     &kafka.LeastBytes{},
+		Balancer:     &kafka.Hash{},
 		RequiredAcks: int(kafka.RequireAll),
 		BatchTimeout: 5 * time.Second,
 		BatchSize:    5,
@@ -72,11 +74,24 @@ func (k *Kafka) Close() {
 	})
 }
 
+// SetTombstones включает отправку пустого сообщения с ключом снятого элемента,
+// чтобы compacted-топики удаляли его
+func (k *Kafka) SetTombstones(enabled bool) {
+	k.tombstones = enabled
+}
+
 func (k *Kafka) Write(item *rss.Item, channel *rss.Channel, isTesting bool, channelCode string) error {
 	kafkaItem := model.Message{
+		Event:     model.EventCreated,
 		NewsItem:  *item,
 		IsTesting: isTesting,
 	}
+	if item.Retracted {
+		kafkaItem.Event = model.EventRetracted
+	} else if item.Update != nil {
+		kafkaItem.Event = model.EventUpdated
+		kafkaItem.Update = item.Update
+	}
 	kafkaChannel := &model.Channel{}
 	kafkaChannel.ConvertFromRSS(channel, channelCode)
 	kafkaItem.Channel = *kafkaChannel
@@ -88,11 +103,17 @@ func (k *Kafka) Write(item *rss.Item, channel *rss.Channel, isTesting bool, chan
 		return err
 	}
 
+	messages := []kafka.Message{{
+		Key:   []byte(item.ID),
+		Value: data,
+	}}
+	if item.Retracted && k.tombstones {
+		messages = append(messages, kafka.Message{Key: []byte(item.ID)})
+	}
+
 	for i := range 3 {
 		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-		err = k.writer.WriteMessages(ctx, kafka.Message{
-			Value: data,
-		})
+		err = k.writer.WriteMessages(ctx, messages...)
 		cancel()
 
 		if err == nil {
@@ -0,0 +1,154 @@
+package implementation
+
+import (
+	"strings"
+
+	"gafarov/rss-reader/internal/core/parser"
+	"gafarov/rss-reader/internal/model/atom"
+	"gafarov/rss-reader/internal/model/rss"
+)
+
+// atomNamespace подставляется лентам Atom без объявленного xmlns
+const atomNamespace = "http://www.w3.org/2005/Atom"
+
+type AtomParser struct{}
+
+func (p *AtomParser) Name() string {
+	return "atom"
+}
+
+func (p *AtomParser) CanParse(probe parser.Probe) bool {
+	return probe.Root.Local == "feed"
+}
+
+func (p *AtomParser) Parse(data []byte, options parser.Options) (*parser.Result, error) {
+	feed, warnings, err := decodeXML(data, options, atomNamespace, "entry", func(f *atom.Feed) *[]atom.Entry {
+		return &f.Entries
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &parser.Result{Channel: convertAtom(feed), Warnings: warnings}, nil
+}
+
+func atomLink(links []atom.Link, rel string) *atom.Link {
+	for i := range links {
+		linkRel := links[i].Rel
+		if linkRel == "" {
+			linkRel = "alternate"
+		}
+		if linkRel == rel {
+			return &links[i]
+		}
+	}
+	return nil
+}
+
+func atomAuthors(persons []atom.Person) string {
+	names := make([]string, 0, len(persons))
+	for _, p := range persons {
+		name := strings.TrimSpace(p.Name)
+		if name == "" {
+			name = strings.TrimSpace(p.Email)
+		}
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func convertAtomEntry(entry *atom.Entry, feedAuthor string) rss.Item {
+	item := rss.Item{
+		Title:       strings.TrimSpace(entry.Title.Value()),
+		Guid:        rss.Guid{Value: strings.TrimSpace(entry.ID), IsPermaLink: "false"},
+		PubDate:     strings.TrimSpace(entry.Published),
+		Description: strings.TrimSpace(entry.Summary.Value()),
+		Fulltext:    strings.TrimSpace(entry.Content.Value()),
+		Author:      atomAuthors(entry.Authors),
+	}
+
+	if item.PubDate == "" {
+		item.PubDate = strings.TrimSpace(entry.Updated)
+	}
+
+	if item.Author == "" {
+		item.Author = feedAuthor
+	}
+
+	if link := atomLink(entry.Links, "alternate"); link != nil {
+		item.Link = link.Href
+	}
+
+	if link := atomLink(entry.Links, "enclosure"); link != nil {
+		item.Enclosure = rss.Enclosure{URL: link.Href, Type: link.Type, Length: link.Length}
+	}
+	for _, link := range entry.Links {
+		if link.Rel == "enclosure" {
+			m := enclosureMedia(link.Href, link.Type, parseInt64(link.Length))
+			m.Title = strings.TrimSpace(link.Title)
+			item.Media = appendMedia(item.Media, m)
+		}
+	}
+
+	for _, category := range entry.Categories {
+		value := category.Term
+		if value == "" {
+			value = category.Label
+		}
+		if value != "" {
+			item.Category = append(item.Category, value)
+		}
+	}
+
+	return item
+}
+
+func convertAtom(feed *atom.Feed) *rss.Channel {
+	channel := &rss.Channel{
+		Title:       strings.TrimSpace(feed.Title.Value()),
+		Description: strings.TrimSpace(feed.Subtitle.Value()),
+		Language:    feed.Lang,
+	}
+
+	if link := atomLink(feed.Links, "alternate"); link != nil {
+		channel.Link = link.Href
+	}
+
+	if link := atomLink(feed.Links, "self"); link != nil {
+		channel.SelfURL = link.Href
+	}
+
+	for _, link := range feed.Links {
+		if link.Rel == "hub" {
+			channel.HubURLs = append(channel.HubURLs, link.Href)
+		}
+	}
+
+	channel.Image.URL = strings.TrimSpace(feed.Logo)
+	if channel.Image.URL == "" {
+		channel.Image.URL = strings.TrimSpace(feed.Icon)
+	}
+	if channel.Image.URL != "" {
+		channel.Image.Title = channel.Title
+		channel.Image.Link = channel.Link
+	}
+
+	channel.LastBuildDate = strings.TrimSpace(feed.Updated)
+	channel.Generator = strings.TrimSpace(feed.Generator.Name)
+	channel.Copyright = strings.TrimSpace(feed.Rights.Value())
+	channel.ManagingEditor = atomAuthors(feed.Authors)
+
+	for _, category := range feed.Categories {
+		if category.Term != "" {
+			channel.Categories = append(channel.Categories, category.Term)
+		}
+	}
+
+	feedAuthor := atomAuthors(feed.Authors)
+	for i := range feed.Entries {
+		channel.Items = append(channel.Items, convertAtomEntry(&feed.Entries[i], feedAuthor))
+	}
+
+	return channel
+}
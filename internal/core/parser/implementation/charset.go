@@ -0,0 +1,94 @@
+package implementation
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+var xmlEncodingPattern = regexp.MustCompile(`^\s*<\?xml[^>]*?\bencoding\s*=\s*["']([A-Za-z0-9._:-]+)["']`)
+
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// ToUTF8 перекодирует документ в UTF-8, определяя кодировку по BOM,
+// заголовку Content-Type и XML-прологу. В прологе кодировка заменяется на UTF-8
+func ToUTF8(data []byte, contentType string) ([]byte, error) {
+	label := detectCharset(data, contentType)
+
+	if isUTF8Label(label) {
+		return rewriteXMLEncoding(bytes.TrimPrefix(data, utf8BOM)), nil
+	}
+
+	encoding, err := htmlindex.Get(label)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCharset, label)
+	}
+
+	decoded, err := encoding.NewDecoder().Bytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", label, err)
+	}
+
+	return rewriteXMLEncoding(bytes.TrimPrefix(decoded, utf8BOM)), nil
+}
+
+func detectCharset(data []byte, contentType string) string {
+	switch {
+	case bytes.HasPrefix(data, utf8BOM):
+		return "utf-8"
+	case bytes.HasPrefix(data, []byte{0xfe, 0xff}):
+		return "utf-16be"
+	case bytes.HasPrefix(data, []byte{0xff, 0xfe}):
+		return "utf-16le"
+	}
+
+	declared := xmlCharset(data)
+
+	if _, params, err := mime.ParseMediaType(contentType); err == nil && params["charset"] != "" {
+		header := strings.ToLower(params["charset"])
+		// Нередко сервер отдает utf-8 в заголовке для документа в другой кодировке
+		if isUTF8Label(header) && declared != "" && !utf8.Valid(data) {
+			return declared
+		}
+		return header
+	}
+
+	return declared
+}
+
+func xmlCharset(data []byte) string {
+	if len(data) > 1024 {
+		data = data[:1024]
+	}
+	match := xmlEncodingPattern.FindSubmatch(data)
+	if match == nil {
+		return ""
+	}
+	return strings.ToLower(string(match[1]))
+}
+
+func isUTF8Label(label string) bool {
+	return label == "" || label == "utf-8" || label == "utf8"
+}
+
+func rewriteXMLEncoding(data []byte) []byte {
+	head := data
+	if len(head) > 1024 {
+		head = head[:1024]
+	}
+	match := xmlEncodingPattern.FindSubmatchIndex(head)
+	if match == nil || isUTF8Label(strings.ToLower(string(data[match[2]:match[3]]))) {
+		return data
+	}
+
+	result := make([]byte, 0, len(data))
+	result = append(result, data[:match[2]]...)
+	result = append(result, "UTF-8"...)
+	result = append(result, data[match[3]:]...)
+	return result
+}
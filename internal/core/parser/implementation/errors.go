@@ -0,0 +1,6 @@
+package implementation
+
+import "errors"
+
+var ErrUnsupportedFormat error = errors.New("unsupported feed format")
+var ErrUnsupportedCharset error = errors.New("unsupported charset")
@@ -0,0 +1,134 @@
+package implementation
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"sync"
+
+	"gafarov/rss-reader/internal/model/rss"
+)
+
+func extraKey(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return "{" + name.Space + "}" + name.Local
+}
+
+// collectExtra переносит нераспознанные элементы и атрибуты в Extra
+func collectExtra(unknown *rss.Unknown) {
+	extra := make(map[string][]string)
+
+	for _, attr := range unknown.UnknownAttrs {
+		if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+			continue
+		}
+		key := "@" + extraKey(attr.Name)
+		extra[key] = append(extra[key], attr.Value)
+	}
+
+	for _, element := range unknown.UnknownElements {
+		key := extraKey(element.XMLName)
+		value := strings.TrimSpace(element.Text)
+		if value == "" {
+			value = strings.TrimSpace(element.InnerXML)
+		}
+		extra[key] = append(extra[key], value)
+
+		for _, attr := range element.Attrs {
+			if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+				continue
+			}
+			attrKey := key + "@" + extraKey(attr.Name)
+			extra[attrKey] = append(extra[attrKey], attr.Value)
+		}
+	}
+
+	unknown.UnknownElements = nil
+	unknown.UnknownAttrs = nil
+	if len(extra) == 0 {
+		unknown.Extra = nil
+		return
+	}
+	unknown.Extra = extra
+}
+
+var jsonFieldsCache sync.Map
+
+// jsonFields возвращает индексы строковых полей структуры по их json-именам
+func jsonFields(t reflect.Type) map[string][]int {
+	if cached, ok := jsonFieldsCache.Load(t); ok {
+		return cached.(map[string][]int)
+	}
+
+	fields := make(map[string][]int)
+	collectJSONFields(t, nil, fields)
+
+	jsonFieldsCache.Store(t, fields)
+	return fields
+}
+
+func collectJSONFields(t reflect.Type, parent []int, fields map[string][]int) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		index := append(append([]int(nil), parent...), i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+
+		// встроенные структуры без json-имени раскрываются в JSON на верхний уровень
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			collectJSONFields(field.Type, index, fields)
+			continue
+		}
+		if name == "" || name == "-" {
+			continue
+		}
+
+		kind := field.Type.Kind()
+		isStrings := kind == reflect.Slice && field.Type.Elem().Kind() == reflect.String
+		if kind != reflect.String && !isStrings {
+			continue
+		}
+		if _, ok := fields[name]; !ok {
+			fields[name] = index
+		}
+	}
+}
+
+// promoteExtra заполняет пустые поля target (указатель на структуру) значениями
+// из Extra согласно mapping: ключ Extra -> json-имя поля
+func promoteExtra(target any, extra map[string][]string, mapping map[string]string) {
+	if len(extra) == 0 || len(mapping) == 0 {
+		return
+	}
+
+	value := reflect.ValueOf(target).Elem()
+	fields := jsonFields(value.Type())
+
+	for key, fieldName := range mapping {
+		values, ok := extra[key]
+		if !ok || len(values) == 0 {
+			continue
+		}
+		index, ok := fields[fieldName]
+		if !ok {
+			continue
+		}
+
+		field := value.FieldByIndex(index)
+		switch field.Kind() {
+		case reflect.String:
+			if field.String() == "" {
+				field.SetString(values[0])
+			}
+		case reflect.Slice:
+			if field.Len() == 0 {
+				field.Set(reflect.ValueOf(append([]string(nil), values...)))
+			}
+		}
+	}
+}
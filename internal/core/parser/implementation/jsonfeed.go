@@ -0,0 +1,133 @@
+package implementation
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"gafarov/rss-reader/internal/core/parser"
+	"gafarov/rss-reader/internal/model/jsonfeed"
+	"gafarov/rss-reader/internal/model/rss"
+)
+
+type JSONFeedParser struct{}
+
+func (p *JSONFeedParser) Name() string {
+	return "jsonfeed"
+}
+
+func (p *JSONFeedParser) CanParse(probe parser.Probe) bool {
+	return probe.IsJSON
+}
+
+func (p *JSONFeedParser) Parse(data []byte, options parser.Options) (*parser.Result, error) {
+	var feed jsonfeed.Feed
+	if err := json.Unmarshal(data, &feed); err != nil {
+		return nil, err
+	}
+	return &parser.Result{Channel: convertJSONFeed(&feed)}, nil
+}
+
+func jsonFeedAuthors(authors []jsonfeed.Author, author *jsonfeed.Author) string {
+	if len(authors) == 0 && author != nil {
+		authors = []jsonfeed.Author{*author}
+	}
+
+	names := make([]string, 0, len(authors))
+	for _, a := range authors {
+		if name := strings.TrimSpace(a.Name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func convertJSONFeedItem(item *jsonfeed.Item, feedAuthor string) rss.Item {
+	result := rss.Item{
+		Title:       strings.TrimSpace(item.Title),
+		Guid:        rss.Guid{Value: strings.TrimSpace(item.ID), IsPermaLink: "false"},
+		PubDate:     item.DatePublished,
+		Link:        item.URL,
+		Description: strings.TrimSpace(item.Summary),
+		Fulltext:    item.ContentHTML,
+		Category:    item.Tags,
+		Author:      jsonFeedAuthors(item.Authors, item.Author),
+	}
+
+	if result.PubDate == "" {
+		result.PubDate = item.DateModified
+	}
+
+	if result.Link == "" {
+		result.Link = item.ExternalURL
+	}
+
+	if result.Fulltext == "" {
+		result.Fulltext = item.ContentText
+	}
+
+	if result.Author == "" {
+		result.Author = feedAuthor
+	}
+
+	if len(item.Attachments) > 0 {
+		result.Enclosure = rss.Enclosure{
+			URL:    item.Attachments[0].URL,
+			Type:   item.Attachments[0].MimeType,
+			Length: sizeInBytes(item.Attachments[0].SizeInBytes),
+		}
+	} else if item.Image != "" {
+		result.Enclosure = rss.Enclosure{URL: item.Image}
+	}
+
+	for _, attachment := range item.Attachments {
+		m := enclosureMedia(attachment.URL, attachment.MimeType, attachment.SizeInBytes)
+		m.Title = strings.TrimSpace(attachment.Title)
+		m.Duration = int(attachment.DurationInSeconds)
+		result.Media = appendMedia(result.Media, m)
+	}
+	if item.Image != "" {
+		m := enclosureMedia(item.Image, "", 0)
+		m.Medium = "image"
+		result.Media = appendMedia(result.Media, m)
+	}
+
+	return result
+}
+
+func sizeInBytes(size int64) string {
+	if size <= 0 {
+		return ""
+	}
+	return strconv.FormatInt(size, 10)
+}
+
+func convertJSONFeed(feed *jsonfeed.Feed) *rss.Channel {
+	channel := &rss.Channel{
+		Title:       strings.TrimSpace(feed.Title),
+		Link:        feed.HomePageURL,
+		Description: strings.TrimSpace(feed.Description),
+		Language:    feed.Language,
+		SelfURL:     feed.FeedURL,
+	}
+
+	channel.Image.URL = feed.Icon
+	if channel.Image.URL == "" {
+		channel.Image.URL = feed.Favicon
+	}
+
+	for _, hub := range feed.Hubs {
+		if hub.URL != "" {
+			channel.HubURLs = append(channel.HubURLs, hub.URL)
+		}
+	}
+
+	channel.ManagingEditor = jsonFeedAuthors(feed.Authors, feed.Author)
+
+	feedAuthor := jsonFeedAuthors(feed.Authors, feed.Author)
+	for i := range feed.Items {
+		channel.Items = append(channel.Items, convertJSONFeedItem(&feed.Items[i], feedAuthor))
+	}
+
+	return channel
+}
@@ -0,0 +1,230 @@
+package implementation
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"maps"
+	"regexp"
+	"unicode/utf8"
+
+	"gafarov/rss-reader/internal/core/parser"
+)
+
+var bareAmpersandPattern = regexp.MustCompile(`&([^&;\s<]{0,32};)?`)
+var cdataStart, cdataEnd = []byte("<![CDATA["), []byte("]]>")
+var entityReferencePattern = regexp.MustCompile(`^&(#[0-9]+|#[xX][0-9a-fA-F]+|[A-Za-z][A-Za-z0-9._-]*);$`)
+
+func newDecoder(data []byte, options parser.Options) *xml.Decoder {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	if !options.Strict {
+		decoder.Strict = false
+		decoder.AutoClose = xml.HTMLAutoClose
+		decoder.Entity = make(map[string]string, len(xml.HTMLEntity)+len(options.Entity))
+		maps.Copy(decoder.Entity, xml.HTMLEntity)
+		maps.Copy(decoder.Entity, options.Entity)
+	}
+	return decoder
+}
+
+// sanitize удаляет недопустимые в XML символы и экранирует одиночные амперсанды
+func sanitize(data []byte) ([]byte, []string) {
+	var warnings []string
+
+	invalid := 0
+	result := make([]byte, 0, len(data))
+	for len(data) > 0 {
+		r, size := utf8.DecodeRune(data)
+		if (r == utf8.RuneError && size == 1) || !isXMLChar(r) {
+			invalid++
+		} else {
+			result = append(result, data[:size]...)
+		}
+		data = data[size:]
+	}
+	if invalid > 0 {
+		warnings = append(warnings, fmt.Sprintf("removed %d invalid characters", invalid))
+	}
+
+	result, escaped := escapeAmpersands(result)
+	if escaped > 0 {
+		warnings = append(warnings, fmt.Sprintf("escaped %d bare ampersands", escaped))
+	}
+
+	return result, warnings
+}
+
+// escapeAmpersands экранирует амперсанды, не начинающие ссылку на сущность,
+// не трогая секции CDATA
+func escapeAmpersands(data []byte) ([]byte, int) {
+	escaped := 0
+	replace := func(match []byte) []byte {
+		if entityReferencePattern.Match(match) {
+			return match
+		}
+		escaped++
+		return append([]byte("&amp;"), match[1:]...)
+	}
+
+	result := make([]byte, 0, len(data))
+	for len(data) > 0 {
+		start := bytes.Index(data, cdataStart)
+		if start < 0 {
+			result = append(result, bareAmpersandPattern.ReplaceAllFunc(data, replace)...)
+			break
+		}
+		result = append(result, bareAmpersandPattern.ReplaceAllFunc(data[:start], replace)...)
+
+		end := bytes.Index(data[start:], cdataEnd)
+		if end < 0 {
+			result = append(result, data[start:]...)
+			break
+		}
+		end += start + len(cdataEnd)
+		result = append(result, data[start:end]...)
+		data = data[end:]
+	}
+
+	return result, escaped
+}
+
+func isXMLChar(r rune) bool {
+	return r == 0x09 || r == 0x0A || r == 0x0D ||
+		(r >= 0x20 && r <= 0xD7FF) ||
+		(r >= 0xE000 && r <= 0xFFFD) ||
+		(r >= 0x10000 && r <= 0x10FFFF)
+}
+
+// decodeXML разбирает документ целиком, а в нестрогом режиме при ошибке
+// отдельно разбирает заголовок ленты и каждый элемент element, пропуская битые.
+// space - пространство имен элементов документа без объявленного xmlns
+func decodeXML[F any, I any](data []byte, options parser.Options, space, element string, items func(*F) *[]I) (*F, []string, error) {
+	var warnings []string
+	if !options.Strict {
+		data, warnings = sanitize(data)
+	}
+
+	decode := func(data []byte, v any) error {
+		decoder := newDecoder(data, options)
+		decoder.DefaultSpace = space
+		return decoder.Decode(v)
+	}
+
+	var feed F
+	err := decode(data, &feed)
+	if err == nil || options.Strict {
+		return &feed, warnings, err
+	}
+
+	warnings = append(warnings, fmt.Sprintf("document is malformed, recovering by %s: %v", element, err))
+
+	rest, elements := splitElements(data, element)
+	feed = *new(F)
+	if err := decode(rest, &feed); err != nil {
+		return nil, warnings, err
+	}
+
+	namespaces := rootNamespaces(data, options)
+	list := items(&feed)
+	*list = nil
+	for i, raw := range elements {
+		var item I
+		if err := decode(withNamespaces(raw, element, namespaces), &item); err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s %d skipped: %v", element, i+1, err))
+			continue
+		}
+		*list = append(*list, item)
+	}
+
+	return &feed, warnings, nil
+}
+
+// splitElements вырезает из документа все элементы с именем local
+func splitElements(data []byte, local string) ([]byte, [][]byte) {
+	open := []byte("<" + local)
+	closing := []byte("</" + local + ">")
+
+	var elements [][]byte
+	rest := make([]byte, 0, len(data))
+	for {
+		start := indexElement(data, open)
+		if start < 0 {
+			break
+		}
+		end := bytes.Index(data[start:], closing)
+		if end < 0 {
+			break
+		}
+		end += start + len(closing)
+
+		rest = append(rest, data[:start]...)
+		elements = append(elements, data[start:end])
+		data = data[end:]
+	}
+
+	return append(rest, data...), elements
+}
+
+func indexElement(data, open []byte) int {
+	offset := 0
+	for {
+		i := bytes.Index(data[offset:], open)
+		if i < 0 {
+			return -1
+		}
+		i += offset
+		next := i + len(open)
+		if next < len(data) && (data[next] == '>' || data[next] == '/' || isSpace(data[next])) {
+			return i
+		}
+		offset = next
+	}
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+func rootNamespaces(data []byte, options parser.Options) []byte {
+	decoder := newDecoder(data, options)
+	for {
+		token, err := decoder.RawToken()
+		if err != nil {
+			return nil
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		var result []byte
+		for _, attr := range start.Attr {
+			switch {
+			case attr.Name.Space == "xmlns":
+				result = fmt.Appendf(result, ` xmlns:%s="%s"`, attr.Name.Local, escapeAttr(attr.Value))
+			case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+				result = fmt.Appendf(result, ` xmlns="%s"`, escapeAttr(attr.Value))
+			}
+		}
+		return result
+	}
+}
+
+func escapeAttr(value string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(value))
+	return buf.String()
+}
+
+// withNamespaces переносит объявления пространств имен корня в вырезанный элемент
+func withNamespaces(raw []byte, local string, namespaces []byte) []byte {
+	if len(namespaces) == 0 {
+		return raw
+	}
+	prefix := len(local) + 1
+	result := make([]byte, 0, len(raw)+len(namespaces))
+	result = append(result, raw[:prefix]...)
+	result = append(result, namespaces...)
+	result = append(result, raw[prefix:]...)
+	return result
+}
@@ -0,0 +1,264 @@
+package implementation
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"gafarov/rss-reader/internal/core/parser"
+)
+
+const (
+	LimitItems = "items"
+	LimitDepth = "depth"
+)
+
+// LimitError - документ превышает ограничение из parser.Options
+type LimitError struct {
+	Kind  string
+	Limit int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("document exceeds %s limit of %d", e.Kind, e.Limit)
+}
+
+// Элементы, которые считаются элементами ленты в RSS, RDF и Atom
+var itemElements = []string{"item", "entry"}
+
+// limitCounter считает вложенность и элементы ленты по мере прохода документа
+type limitCounter struct {
+	maxDepth int
+	maxItems int
+	depth    int
+	items    int
+}
+
+func (c *limitCounter) open(isItem bool) error {
+	c.depth++
+	if c.maxDepth > 0 && c.depth > c.maxDepth {
+		return &LimitError{Kind: LimitDepth, Limit: c.maxDepth}
+	}
+	if isItem {
+		c.items++
+		if c.maxItems > 0 && c.items > c.maxItems {
+			return &LimitError{Kind: LimitItems, Limit: c.maxItems}
+		}
+	}
+	return nil
+}
+
+func (c *limitCounter) close() {
+	if c.depth > 0 {
+		c.depth--
+	}
+}
+
+// checkLimits проходит документ без построения дерева и проверяет вложенность и число
+// элементов до разбора: проход останавливается на первом превышении, поэтому большие
+// и глубокие документы не доходят до парсеров
+func checkLimits(data []byte, isJSON bool, options parser.Options) error {
+	if options.MaxDepth <= 0 && options.MaxItems <= 0 {
+		return nil
+	}
+	counter := &limitCounter{maxDepth: options.MaxDepth, maxItems: options.MaxItems}
+	if isJSON {
+		return checkJSONLimits(data, counter)
+	}
+	return checkXMLLimits(data, counter)
+}
+
+func checkXMLLimits(data []byte, counter *limitCounter) error {
+	// незакрытые HTML-теги вроде <br> не должны увеличивать глубину
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			// нестрогий парсер восстановит и такой документ, поэтому проверка
+			// продолжается по тегам без разбора синтаксиса
+			return scanXMLLimits(data, &limitCounter{maxDepth: counter.maxDepth, maxItems: counter.maxItems})
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			if err := counter.open(slices.Contains(itemElements, token.Name.Local)); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			counter.close()
+		}
+	}
+}
+
+var commentStart, commentEnd = []byte("<!--"), []byte("-->")
+
+// scanXMLLimits считает открывающие и закрывающие теги в документе с синтаксическими ошибками
+func scanXMLLimits(data []byte, counter *limitCounter) error {
+	for i := 0; i < len(data); i++ {
+		if data[i] != '<' {
+			continue
+		}
+		rest := data[i:]
+
+		var end int
+		switch {
+		case bytes.HasPrefix(rest, commentStart):
+			end = skipTo(rest, commentEnd)
+		case bytes.HasPrefix(rest, cdataStart):
+			end = skipTo(rest, cdataEnd)
+		case bytes.HasPrefix(rest, []byte("<!")), bytes.HasPrefix(rest, []byte("<?")):
+			end = skipTo(rest, []byte(">"))
+		case bytes.HasPrefix(rest, []byte("</")):
+			if !isAutoClose(tagName(rest[2:])) {
+				counter.close()
+			}
+			end = skipTo(rest, []byte(">"))
+		default:
+			name := tagName(rest[1:])
+			if name == "" {
+				continue
+			}
+			end = tagEnd(rest)
+			if end < 0 {
+				return nil
+			}
+			if rest[end-2] == '/' || isAutoClose(name) {
+				break
+			}
+			if err := counter.open(slices.Contains(itemElements, localName(name))); err != nil {
+				return err
+			}
+		}
+
+		if end < 0 {
+			return nil
+		}
+		i += end - 1
+	}
+	return nil
+}
+
+// skipTo возвращает позицию после marker или -1
+func skipTo(data, marker []byte) int {
+	i := bytes.Index(data, marker)
+	if i < 0 {
+		return -1
+	}
+	return i + len(marker)
+}
+
+// tagEnd возвращает позицию после '>' открывающего тега с учетом кавычек в атрибутах
+func tagEnd(data []byte) int {
+	var quote byte
+	for i := 1; i < len(data); i++ {
+		switch {
+		case quote != 0:
+			if data[i] == quote {
+				quote = 0
+			}
+		case data[i] == '"' || data[i] == '\'':
+			quote = data[i]
+		case data[i] == '>':
+			return i + 1
+		}
+	}
+	return -1
+}
+
+func tagName(data []byte) string {
+	end := 0
+	for end < len(data) {
+		b := data[end]
+		if b == '>' || b == '/' || isSpace(b) {
+			break
+		}
+		end++
+	}
+	name := string(data[:end])
+	if name == "" || !(name[0] == '_' || name[0] == ':' || (name[0]|0x20 >= 'a' && name[0]|0x20 <= 'z')) {
+		return ""
+	}
+	return name
+}
+
+func localName(name string) string {
+	if i := strings.LastIndexByte(name, ':'); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+func isAutoClose(name string) bool {
+	name = strings.ToLower(localName(name))
+	return slices.Contains(xml.HTMLAutoClose, name)
+}
+
+// jsonLevel - открытый объект или массив документа JSON
+type jsonLevel struct {
+	object    bool
+	expectKey bool
+	items     bool
+}
+
+func checkJSONLimits(data []byte, counter *limitCounter) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	var stack []jsonLevel
+	// itemsKey - значение по ключу "items" корневого объекта
+	itemsKey := false
+	value := func() {
+		if len(stack) > 0 && stack[len(stack)-1].object {
+			stack[len(stack)-1].expectKey = true
+		}
+	}
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			// синтаксические ошибки JSON Feed парсер не восстанавливает
+			return nil
+		}
+
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			isItem := len(stack) == 2 && stack[1].items
+			value()
+			stack = append(stack, jsonLevel{
+				object:    token == json.Delim('{'),
+				expectKey: token == json.Delim('{'),
+				items:     itemsKey && token == json.Delim('['),
+			})
+			itemsKey = false
+			if err := counter.open(isItem); err != nil {
+				return err
+			}
+			continue
+		case json.Delim('}'), json.Delim(']'):
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			counter.close()
+			continue
+		}
+
+		if len(stack) > 0 && stack[len(stack)-1].expectKey {
+			stack[len(stack)-1].expectKey = false
+			itemsKey = len(stack) == 1 && token == "items"
+			continue
+		}
+		value()
+		itemsKey = false
+	}
+}
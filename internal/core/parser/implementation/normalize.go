@@ -0,0 +1,193 @@
+package implementation
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"gafarov/rss-reader/internal/model/rss"
+)
+
+// normalizeItem собирает медиаобъекты из Media RSS и enclosure и
+// заполняет пустые поля из iTunes, Dublin Core и расширений Яндекса
+func normalizeItem(item *rss.Item) {
+	if item.Fulltext == "" {
+		item.Fulltext = item.YandexFullText
+	}
+	if item.Author == "" {
+		item.Author = strings.TrimSpace(item.DCCreator)
+	}
+	if item.Author == "" {
+		item.Author = strings.TrimSpace(item.ITunesAuthor)
+	}
+	if item.PubDate == "" {
+		item.PubDate = strings.TrimSpace(item.DCDate)
+	}
+	if item.Description == "" {
+		item.Description = strings.TrimSpace(item.MediaDescription)
+	}
+	if item.Description == "" {
+		item.Description = strings.TrimSpace(item.ITunesSummary)
+	}
+
+	item.Media = collectMedia(item)
+}
+
+// normalizeChannel разбирает atom:link и подставляет логотип из iTunes
+func normalizeChannel(channel *rss.Channel) {
+	for _, link := range channel.AtomLinks {
+		switch link.Rel {
+		case "self":
+			if channel.SelfURL == "" {
+				channel.SelfURL = strings.TrimSpace(link.Href)
+			}
+		case "hub":
+			channel.HubURLs = append(channel.HubURLs, strings.TrimSpace(link.Href))
+		case "", "alternate":
+			if channel.Link == "" {
+				channel.Link = strings.TrimSpace(link.Href)
+			}
+		}
+	}
+
+	if channel.Image.URL == "" {
+		channel.Image.URL = strings.TrimSpace(channel.ITunesImage.Href)
+	}
+}
+
+func collectMedia(item *rss.Item) []rss.Media {
+	var media []rss.Media
+	seen := make(map[string]struct{})
+	add := func(m rss.Media) {
+		if m.URL == "" {
+			return
+		}
+		if _, ok := seen[m.URL]; ok {
+			return
+		}
+		seen[m.URL] = struct{}{}
+		media = append(media, m)
+	}
+
+	for _, group := range item.MediaGroups {
+		for _, content := range group.Contents {
+			m := convertMediaContent(content)
+			if m.Title == "" {
+				m.Title = strings.TrimSpace(group.Title)
+			}
+			if m.Description == "" {
+				m.Description = strings.TrimSpace(group.Description)
+			}
+			if len(m.Thumbnails) == 0 {
+				m.Thumbnails = convertThumbnails(group.Thumbnails)
+			}
+			add(m)
+		}
+	}
+
+	for _, content := range item.MediaContents {
+		add(convertMediaContent(content))
+	}
+
+	if item.Enclosure.URL != "" {
+		add(enclosureMedia(item.Enclosure.URL, item.Enclosure.Type, parseInt64(item.Enclosure.Length)))
+	}
+
+	thumbnails := convertThumbnails(item.MediaThumbnails)
+	if len(media) == 0 {
+		for _, thumbnail := range thumbnails {
+			add(rss.Media{
+				URL:    thumbnail.URL,
+				Medium: "image",
+				Width:  thumbnail.Width,
+				Height: thumbnail.Height,
+			})
+		}
+	}
+
+	for i := range media {
+		if len(media[i].Thumbnails) == 0 {
+			media[i].Thumbnails = thumbnails
+		}
+		if media[i].Title == "" {
+			media[i].Title = strings.TrimSpace(item.MediaTitle)
+		}
+		if media[i].Description == "" {
+			media[i].Description = strings.TrimSpace(item.MediaDescription)
+		}
+	}
+
+	return media
+}
+
+// enclosureMedia - медиаобъект из enclosure RSS, ссылки rel="enclosure" Atom,
+// вложения RDF или attachment JSON Feed
+func enclosureMedia(url, contentType string, length int64) rss.Media {
+	return rss.Media{
+		URL:    strings.TrimSpace(url),
+		Type:   strings.TrimSpace(contentType),
+		Medium: mediumFromType(contentType),
+		Length: length,
+	}
+}
+
+// appendMedia пропускает пустые и повторяющиеся адреса
+func appendMedia(media []rss.Media, m rss.Media) []rss.Media {
+	if m.URL == "" || slices.ContainsFunc(media, func(other rss.Media) bool { return other.URL == m.URL }) {
+		return media
+	}
+	return append(media, m)
+}
+
+func convertMediaContent(content rss.MediaContent) rss.Media {
+	medium := strings.TrimSpace(content.Medium)
+	if medium == "" {
+		medium = mediumFromType(content.Type)
+	}
+	return rss.Media{
+		URL:         strings.TrimSpace(content.URL),
+		Type:        strings.TrimSpace(content.Type),
+		Medium:      medium,
+		Length:      parseInt64(content.FileSize),
+		Width:       parseInt(content.Width),
+		Height:      parseInt(content.Height),
+		Duration:    parseInt(content.Duration),
+		Title:       strings.TrimSpace(content.Title),
+		Description: strings.TrimSpace(content.Description),
+		Thumbnails:  convertThumbnails(content.Thumbnails),
+	}
+}
+
+func convertThumbnails(thumbnails []rss.MediaThumbnail) []rss.Thumbnail {
+	var result []rss.Thumbnail
+	for _, t := range thumbnails {
+		if url := strings.TrimSpace(t.URL); url != "" {
+			result = append(result, rss.Thumbnail{
+				URL:    url,
+				Width:  parseInt(t.Width),
+				Height: parseInt(t.Height),
+			})
+		}
+	}
+	return result
+}
+
+func mediumFromType(contentType string) string {
+	medium, _, _ := strings.Cut(strings.TrimSpace(contentType), "/")
+	switch medium {
+	case "image", "audio", "video":
+		return medium
+	default:
+		return ""
+	}
+}
+
+func parseInt(s string) int {
+	v, _ := strconv.Atoi(strings.TrimSpace(s))
+	return v
+}
+
+func parseInt64(s string) int64 {
+	v, _ := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	return v
+}
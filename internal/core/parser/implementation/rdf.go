@@ -0,0 +1,84 @@
+package implementation
+
+import (
+	"strings"
+
+	"gafarov/rss-reader/internal/core/parser"
+	"gafarov/rss-reader/internal/model/rdf"
+	"gafarov/rss-reader/internal/model/rss"
+)
+
+type RDFParser struct{}
+
+func (p *RDFParser) Name() string {
+	return "rdf"
+}
+
+func (p *RDFParser) CanParse(probe parser.Probe) bool {
+	return probe.Root.Local == "RDF"
+}
+
+func (p *RDFParser) Parse(data []byte, options parser.Options) (*parser.Result, error) {
+	feed, warnings, err := decodeXML(data, options, "", "item", func(f *rdf.RDF) *[]rdf.Item {
+		return &f.Items
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &parser.Result{Channel: convertRDF(feed), Warnings: warnings}, nil
+}
+
+func convertRDFItem(item *rdf.Item) rss.Item {
+	result := rss.Item{
+		Title:       strings.TrimSpace(item.Title),
+		Guid:        rss.Guid{Value: strings.TrimSpace(item.About)},
+		PubDate:     strings.TrimSpace(item.Date),
+		Link:        strings.TrimSpace(item.Link),
+		Description: strings.TrimSpace(item.Description),
+		Fulltext:    item.Fulltext,
+		Category:    item.Subject,
+		Author:      strings.TrimSpace(item.Creator),
+	}
+
+	if result.Guid.Value == "" {
+		result.Guid.Value = result.Link
+	}
+
+	if len(item.Enclosures) > 0 {
+		result.Enclosure = rss.Enclosure{
+			URL:    item.Enclosures[0].URL,
+			Type:   item.Enclosures[0].Type,
+			Length: item.Enclosures[0].Length,
+		}
+	}
+	for _, enclosure := range item.Enclosures {
+		result.Media = appendMedia(result.Media, enclosureMedia(enclosure.URL, enclosure.Type, parseInt64(enclosure.Length)))
+	}
+
+	return result
+}
+
+func convertRDF(feed *rdf.RDF) *rss.Channel {
+	channel := &rss.Channel{
+		Title:       strings.TrimSpace(feed.Channel.Title),
+		Link:        strings.TrimSpace(feed.Channel.Link),
+		Description: strings.TrimSpace(feed.Channel.Description),
+		Language:    strings.TrimSpace(feed.Channel.Language),
+		PubDate:     strings.TrimSpace(feed.Channel.Date),
+		Copyright:   strings.TrimSpace(feed.Channel.Rights),
+		SelfURL:     strings.TrimSpace(feed.Channel.About),
+		Image: rss.Image{
+			URL:   strings.TrimSpace(feed.Image.URL),
+			Title: strings.TrimSpace(feed.Image.Title),
+			Link:  strings.TrimSpace(feed.Image.Link),
+		},
+	}
+
+	channel.ManagingEditor = strings.TrimSpace(feed.Channel.Publisher)
+
+	for i := range feed.Items {
+		channel.Items = append(channel.Items, convertRDFItem(&feed.Items[i]))
+	}
+
+	return channel
+}
@@ -0,0 +1,110 @@
+package implementation
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"strings"
+	"sync"
+
+	"gafarov/rss-reader/internal/core/parser"
+)
+
+type Registry struct {
+	mu      sync.RWMutex
+	parsers []parser.IParser
+}
+
+func New() *Registry {
+	r := &Registry{}
+	r.Register(&RSSParser{})
+	r.Register(&AtomParser{})
+	r.Register(&RDFParser{})
+	r.Register(&JSONFeedParser{})
+	return r
+}
+
+// Register добавляет парсер; зарегистрированные позже имеют приоритет
+func (r *Registry) Register(p parser.IParser) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.parsers = append(r.parsers, p)
+}
+
+func (r *Registry) Detect(data []byte, contentType string) (parser.IParser, error) {
+	probe := NewProbe(data, contentType)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for i := len(r.parsers) - 1; i >= 0; i-- {
+		if r.parsers[i].CanParse(probe) {
+			return r.parsers[i], nil
+		}
+	}
+
+	switch {
+	case probe.IsJSON:
+		return nil, fmt.Errorf("%w: json (%s)", ErrUnsupportedFormat, probe.ContentType)
+	case probe.Root.Local != "":
+		return nil, fmt.Errorf("%w: <%s> (%s)", ErrUnsupportedFormat, probe.Root.Local, probe.ContentType)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, probe.ContentType)
+	}
+}
+
+func (r *Registry) Parse(data []byte, contentType string, options parser.Options) (*parser.Result, error) {
+	data, err := ToUTF8(data, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	probe := NewProbe(data, contentType)
+	if err := checkLimits(data, probe.IsJSON, options); err != nil {
+		return nil, err
+	}
+
+	p, err := r.Detect(data, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := p.Parse(data, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.MaxItems > 0 && len(result.Channel.Items) > options.MaxItems {
+		return nil, &LimitError{Kind: LimitItems, Limit: options.MaxItems}
+	}
+	return result, nil
+}
+
+func NewProbe(data []byte, contentType string) parser.Probe {
+	probe := parser.Probe{}
+
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		probe.ContentType = mediaType
+	} else {
+		probe.ContentType = strings.ToLower(strings.TrimSpace(contentType))
+	}
+
+	data = bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if len(data) > 0 && data[0] == '{' {
+		probe.IsJSON = true
+		return probe
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return probe
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			probe.Root = start.Name
+			return probe
+		}
+	}
+}
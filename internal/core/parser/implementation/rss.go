@@ -0,0 +1,36 @@
+package implementation
+
+import (
+	"gafarov/rss-reader/internal/core/parser"
+	"gafarov/rss-reader/internal/model/rss"
+)
+
+type RSSParser struct{}
+
+func (p *RSSParser) Name() string {
+	return "rss"
+}
+
+func (p *RSSParser) CanParse(probe parser.Probe) bool {
+	return probe.Root.Local == "rss"
+}
+
+func (p *RSSParser) Parse(data []byte, options parser.Options) (*parser.Result, error) {
+	feed, warnings, err := decodeXML(data, options, "", "item", func(f *rss.Rss) *[]rss.Item {
+		return &f.Channel.Items
+	})
+	if err != nil {
+		return nil, err
+	}
+	normalizeChannel(&feed.Channel)
+	collectExtra(&feed.Channel.Unknown)
+	promoteExtra(&feed.Channel, feed.Channel.Extra, options.Promote)
+
+	for i := range feed.Channel.Items {
+		item := &feed.Channel.Items[i]
+		collectExtra(&item.Unknown)
+		promoteExtra(item, item.Extra, options.Promote)
+		normalizeItem(item)
+	}
+	return &parser.Result{Channel: &feed.Channel, Warnings: warnings}, nil
+}
@@ -0,0 +1,67 @@
+package implementation_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gafarov/rss-reader/internal/core/parser"
+	"gafarov/rss-reader/internal/core/parser/implementation"
+)
+
+const partnerRSS = `<rss version="2.0" xmlns:p="http://partner.example.com/ns"><channel>` +
+	`<title>Partner</title><p:brand color="red">Partner Brand</p:brand>` +
+	`<item turbo="true" p:id="42"><title>Item</title><guid>1</guid>` +
+	`<p:lead><![CDATA[Lead text]]></p:lead><p:tag>a</p:tag><p:tag>b</p:tag>` +
+	`<rating>5</rating><p:source url="https://partner.example.com/1"/>` +
+	`</item></channel></rss>`
+
+func TestRegistry_CollectsExtra(t *testing.T) {
+	registry := implementation.New()
+
+	result, err := registry.Parse([]byte(partnerRSS), "text/xml", parser.Options{Strict: true})
+	assert.NoError(t, err)
+
+	channel := result.Channel
+	assert.Equal(t, []string{"Partner Brand"}, channel.Extra["{http://partner.example.com/ns}brand"])
+	assert.Equal(t, []string{"red"}, channel.Extra["{http://partner.example.com/ns}brand@color"])
+
+	item := channel.Items[0]
+	assert.Equal(t, []string{"true"}, item.Extra["@turbo"])
+	assert.Equal(t, []string{"42"}, item.Extra["@{http://partner.example.com/ns}id"])
+	assert.Equal(t, []string{"Lead text"}, item.Extra["{http://partner.example.com/ns}lead"])
+	assert.Equal(t, []string{"a", "b"}, item.Extra["{http://partner.example.com/ns}tag"])
+	assert.Equal(t, []string{"5"}, item.Extra["rating"])
+	assert.Equal(t, []string{"https://partner.example.com/1"}, item.Extra["{http://partner.example.com/ns}source@url"])
+	assert.Empty(t, item.UnknownElements)
+
+	data, err := json.Marshal(item)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"extra":{`)
+	assert.Contains(t, string(data), `"rating":["5"]`)
+}
+
+func TestRegistry_PromotesExtra(t *testing.T) {
+	registry := implementation.New()
+	options := parser.Options{
+		Strict: true,
+		Promote: map[string]string{
+			"{http://partner.example.com/ns}lead":  "description",
+			"{http://partner.example.com/ns}tag":   "category",
+			"{http://partner.example.com/ns}brand": "description",
+			"rating":                               "unknownField",
+			"{http://partner.example.com/ns}title": "title",
+		},
+	}
+
+	result, err := registry.Parse([]byte(partnerRSS), "text/xml", options)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Partner Brand", result.Channel.Description)
+
+	item := result.Channel.Items[0]
+	assert.Equal(t, "Item", item.Title)
+	assert.Equal(t, "Lead text", item.Description)
+	assert.Equal(t, []string{"a", "b"}, item.Category)
+}
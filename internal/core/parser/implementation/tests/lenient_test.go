@@ -0,0 +1,66 @@
+package implementation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gafarov/rss-reader/internal/core/parser"
+	"gafarov/rss-reader/internal/core/parser/implementation"
+)
+
+const malformedRSS = "<?xml version=\"1.0\"?>\n" +
+	`<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/"><channel>` +
+	`<title>News & Views</title>` +
+	`<item><title>Tom & Jerry&nbsp;return` + "\x01" + `</title><guid>1</guid>` +
+	`<content:encoded><![CDATA[<p>A & B</p>]]></content:encoded><description>&laquo;quoted&raquo; &partner;</description></item>` +
+	`<item><title>Second</title><guid>2</guid></item>` +
+	`</channel></rss>`
+
+const brokenItemRSS = `<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/"><channel><title>Feed</title>` +
+	`<item><title>First</title><guid>1</guid><content:encoded>full</content:encoded></item>` +
+	`<item><title>Broken</title><guid>2</guid><description><![CDATA[unterminated</description></item>` +
+	`<item><title>Third</title><guid>3</guid></item>` +
+	`</channel></rss>`
+
+func TestRegistry_StrictRejectsMalformed(t *testing.T) {
+	registry := implementation.New()
+
+	_, err := registry.Parse([]byte(malformedRSS), "text/xml", parser.Options{Strict: true})
+	assert.Error(t, err)
+}
+
+func TestRegistry_LenientRecoversMalformed(t *testing.T) {
+	registry := implementation.New()
+	options := parser.Options{Entity: map[string]string{"partner": "Партнер"}}
+
+	result, err := registry.Parse([]byte(malformedRSS), "text/xml", options)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result.Warnings)
+
+	channel := result.Channel
+	assert.Equal(t, "News & Views", channel.Title)
+	assert.Len(t, channel.Items, 2)
+	assert.Equal(t, "Tom & Jerry\u00a0return", channel.Items[0].Title)
+	assert.Equal(t, "<p>A & B</p>", channel.Items[0].Fulltext)
+	assert.Equal(t, "«quoted» Партнер", channel.Items[0].Description)
+	assert.Equal(t, "Second", channel.Items[1].Title)
+}
+
+func TestRegistry_LenientSkipsBrokenItem(t *testing.T) {
+	registry := implementation.New()
+
+	_, err := registry.Parse([]byte(brokenItemRSS), "text/xml", parser.Options{Strict: true})
+	assert.Error(t, err)
+
+	result, err := registry.Parse([]byte(brokenItemRSS), "text/xml", parser.Options{})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result.Warnings)
+
+	channel := result.Channel
+	assert.Equal(t, "Feed", channel.Title)
+	assert.Len(t, channel.Items, 2)
+	assert.Equal(t, "First", channel.Items[0].Title)
+	assert.Equal(t, "full", channel.Items[0].Fulltext)
+	assert.Equal(t, "Third", channel.Items[1].Title)
+}
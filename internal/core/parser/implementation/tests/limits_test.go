@@ -0,0 +1,96 @@
+package implementation_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gafarov/rss-reader/internal/core/parser"
+	"gafarov/rss-reader/internal/core/parser/implementation"
+)
+
+func TestRegistry_MaxItems(t *testing.T) {
+	data := []byte(`<rss version="2.0"><channel><title>Feed</title>` +
+		strings.Repeat(`<item><title>Новость</title></item>`, 3) + `</channel></rss>`)
+
+	registry := implementation.New()
+
+	_, err := registry.Parse(data, "application/rss+xml", parser.Options{Strict: true, MaxItems: 3})
+	assert.NoError(t, err)
+
+	_, err = registry.Parse(data, "application/rss+xml", parser.Options{Strict: true, MaxItems: 2})
+	var limitErr *implementation.LimitError
+	if assert.ErrorAs(t, err, &limitErr) {
+		assert.Equal(t, implementation.LimitItems, limitErr.Kind)
+		assert.Equal(t, 2, limitErr.Limit)
+	}
+}
+
+func TestRegistry_MaxDepth(t *testing.T) {
+	registry := implementation.New()
+	var limitErr *implementation.LimitError
+
+	nested := strings.Repeat("<div>", 10) + strings.Repeat("</div>", 10)
+	data := []byte(`<rss version="2.0"><channel><item><description>` + nested + `</description></item></channel></rss>`)
+
+	_, err := registry.Parse(data, "application/rss+xml", parser.Options{Strict: true, MaxDepth: 8})
+	if assert.ErrorAs(t, err, &limitErr) {
+		assert.Equal(t, implementation.LimitDepth, limitErr.Kind)
+	}
+
+	// незакрытые HTML-теги в нестрогом режиме глубину не увеличивают
+	html := []byte(`<rss version="2.0"><channel><item><description>` + strings.Repeat("<br>", 20) +
+		`</description></item></channel></rss>`)
+	_, err = registry.Parse(html, "application/rss+xml", parser.Options{MaxDepth: 8})
+	assert.NoError(t, err)
+
+	json := []byte(`{"version":"https://jsonfeed.org/version/1.1","title":"Feed","items":[{"id":"1","_ext":` +
+		strings.Repeat(`{"a":`, 10) + `1` + strings.Repeat(`}`, 10) + `}]}`)
+	_, err = registry.Parse(json, "application/feed+json", parser.Options{MaxDepth: 8})
+	if assert.ErrorAs(t, err, &limitErr) {
+		assert.Equal(t, implementation.LimitDepth, limitErr.Kind)
+	}
+
+	_, err = registry.Parse(json, "application/feed+json", parser.Options{MaxDepth: 20})
+	assert.NoError(t, err)
+}
+
+func TestRegistry_LimitsMalformed(t *testing.T) {
+	registry := implementation.New()
+	var limitErr *implementation.LimitError
+
+	// синтаксическая ошибка до глубокой вложенности не отключает проверку
+	nested := strings.Repeat("<div>", 20) + strings.Repeat("</div>", 20)
+	data := []byte(`<rss version="2.0"><channel><title>Feed & </wrong></title><item><description>` + nested +
+		`</description></item></channel></rss>`)
+	_, err := registry.Parse(data, "application/rss+xml", parser.Options{MaxDepth: 8})
+	if assert.ErrorAs(t, err, &limitErr) {
+		assert.Equal(t, implementation.LimitDepth, limitErr.Kind)
+	}
+
+	items := []byte(`<rss version="2.0"><channel><title>Feed</wrong><!-- <item> -->` +
+		strings.Repeat(`<item><title>Новость<br></title><link href="a>b"/></item>`, 3) + `</channel></rss>`)
+	_, err = registry.Parse(items, "application/rss+xml", parser.Options{MaxItems: 2, MaxDepth: 8})
+	if assert.ErrorAs(t, err, &limitErr) {
+		assert.Equal(t, implementation.LimitItems, limitErr.Kind)
+	}
+
+	_, err = registry.Parse(items, "application/rss+xml", parser.Options{MaxItems: 3, MaxDepth: 8})
+	assert.NoError(t, err)
+}
+
+func TestRegistry_MaxItemsJSON(t *testing.T) {
+	registry := implementation.New()
+	data := []byte(`{"version":"https://jsonfeed.org/version/1.1","title":"Feed","authors":[{"name":"a"},{"name":"b"},{"name":"c"}],` +
+		`"items":[{"id":"1","_ext":{"a":[{"x":1}]}},{"id":"2"},{"id":"3"}]}`)
+
+	_, err := registry.Parse(data, "application/feed+json", parser.Options{MaxItems: 3})
+	assert.NoError(t, err)
+
+	_, err = registry.Parse(data, "application/feed+json", parser.Options{MaxItems: 2})
+	var limitErr *implementation.LimitError
+	if assert.ErrorAs(t, err, &limitErr) {
+		assert.Equal(t, implementation.LimitItems, limitErr.Kind)
+	}
+}
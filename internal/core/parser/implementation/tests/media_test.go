@@ -0,0 +1,56 @@
+package implementation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gafarov/rss-reader/internal/core/parser"
+	"gafarov/rss-reader/internal/core/parser/implementation"
+	"gafarov/rss-reader/internal/model/rss"
+)
+
+func parseMedia(t *testing.T, data, contentType string) []rss.Media {
+	result, err := implementation.New().Parse([]byte(data), contentType, parser.Options{Strict: true})
+	require.NoError(t, err)
+	require.Len(t, result.Channel.Items, 1)
+	return result.Channel.Items[0].Media
+}
+
+func TestParse_AtomEnclosures(t *testing.T) {
+	media := parseMedia(t, `<feed xmlns="http://www.w3.org/2005/Atom"><entry><id>1</id><title>Выпуск</title>`+
+		`<link rel="alternate" href="https://example.com/1"/>`+
+		`<link rel="enclosure" href="https://example.com/1.mp3" type="audio/mpeg" length="1024" title="Аудио"/>`+
+		`<link rel="enclosure" href="https://example.com/1.jpg" type="image/jpeg"/>`+
+		`</entry></feed>`, "application/atom+xml")
+
+	assert.Equal(t, []rss.Media{
+		{URL: "https://example.com/1.mp3", Type: "audio/mpeg", Medium: "audio", Length: 1024, Title: "Аудио"},
+		{URL: "https://example.com/1.jpg", Type: "image/jpeg", Medium: "image"},
+	}, media)
+}
+
+func TestParse_RDFEnclosures(t *testing.T) {
+	media := parseMedia(t, `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/"`+
+		` xmlns:enc="http://purl.oclc.org/net/rss_2.0/enc#"><item rdf:about="https://example.com/1"><title>Новость</title>`+
+		`<enc:enclosure rdf:resource="https://example.com/1.mp4" enc:type="video/mp4" enc:length="2048"/>`+
+		`</item></rdf:RDF>`, "application/rdf+xml")
+
+	assert.Equal(t, []rss.Media{
+		{URL: "https://example.com/1.mp4", Type: "video/mp4", Medium: "video", Length: 2048},
+	}, media)
+}
+
+func TestParse_JSONFeedAttachments(t *testing.T) {
+	media := parseMedia(t, `{"version":"https://jsonfeed.org/version/1.1","title":"Feed","items":[{"id":"1",`+
+		`"image":"https://example.com/1.jpg","attachments":[`+
+		`{"url":"https://example.com/1.mp3","mime_type":"audio/mpeg","size_in_bytes":1024,"duration_in_seconds":61,"title":"Аудио"},`+
+		`{"url":"https://example.com/1.m4a","mime_type":"audio/mp4"}]}]}`, "application/feed+json")
+
+	assert.Equal(t, []rss.Media{
+		{URL: "https://example.com/1.mp3", Type: "audio/mpeg", Medium: "audio", Length: 1024, Duration: 61, Title: "Аудио"},
+		{URL: "https://example.com/1.m4a", Type: "audio/mp4", Medium: "audio"},
+		{URL: "https://example.com/1.jpg", Medium: "image"},
+	}, media)
+}
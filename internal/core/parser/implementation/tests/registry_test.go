@@ -0,0 +1,85 @@
+package implementation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gafarov/rss-reader/internal/core/parser"
+	"gafarov/rss-reader/internal/core/parser/implementation"
+	"gafarov/rss-reader/internal/model/rss"
+)
+
+type partnerParser struct{}
+
+func (p *partnerParser) Name() string {
+	return "partner"
+}
+
+func (p *partnerParser) CanParse(probe parser.Probe) bool {
+	return probe.ContentType == "application/vnd.partner+xml" || probe.Root.Local == "news"
+}
+
+func (p *partnerParser) Parse(data []byte, options parser.Options) (*parser.Result, error) {
+	channel := &rss.Channel{Title: "partner", Items: []rss.Item{{Title: string(data)}}}
+	return &parser.Result{Channel: channel}, nil
+}
+
+func TestRegistry_Detect(t *testing.T) {
+	cases := []struct {
+		name        string
+		data        string
+		contentType string
+		expected    string
+	}{
+		{"rss", `<?xml version="1.0"?><rss version="2.0"><channel/></rss>`, "application/rss+xml", "rss"},
+		{"atom", `<feed xmlns="http://www.w3.org/2005/Atom"></feed>`, "application/atom+xml; charset=utf-8", "atom"},
+		{"rdf", `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"></rdf:RDF>`, "text/xml", "rdf"},
+		{"json", "\xef\xbb\xbf  {\"version\": \"https://jsonfeed.org/version/1.1\"}", "application/feed+json", "jsonfeed"},
+		{"rss with wrong content type", `<rss version="2.0"><channel/></rss>`, "text/html", "rss"},
+	}
+
+	registry := implementation.New()
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p, err := registry.Detect([]byte(c.data), c.contentType)
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, p.Name())
+		})
+	}
+}
+
+func TestRegistry_Unsupported(t *testing.T) {
+	registry := implementation.New()
+
+	_, err := registry.Parse([]byte("<html><body>Not found</body></html>"), "text/html", parser.Options{Strict: true})
+	assert.True(t, errors.Is(err, implementation.ErrUnsupportedFormat))
+
+	_, err = registry.Parse([]byte("Service Unavailable"), "text/plain", parser.Options{Strict: true})
+	assert.True(t, errors.Is(err, implementation.ErrUnsupportedFormat))
+}
+
+func TestRegistry_CustomParser(t *testing.T) {
+	registry := implementation.New()
+	registry.Register(&partnerParser{})
+
+	result, err := registry.Parse([]byte("<news/>"), "text/xml", parser.Options{Strict: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "partner", result.Channel.Title)
+
+	p, err := registry.Detect([]byte(`<rss version="2.0"/>`), "application/vnd.partner+xml")
+	assert.NoError(t, err)
+	assert.Equal(t, "partner", p.Name())
+
+	p, err = registry.Detect([]byte(`<rss version="2.0"/>`), "application/rss+xml")
+	assert.NoError(t, err)
+	assert.Equal(t, "rss", p.Name())
+}
+
+func TestRegistry_UnsupportedCharset(t *testing.T) {
+	registry := implementation.New()
+
+	_, err := registry.Parse([]byte(`<?xml version="1.0" encoding="x-unknown"?><rss/>`), "text/xml", parser.Options{Strict: true})
+	assert.True(t, errors.Is(err, implementation.ErrUnsupportedCharset))
+}
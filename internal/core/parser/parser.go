@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"encoding/xml"
+
+	"gafarov/rss-reader/internal/model/rss"
+)
+
+// Probe содержит то, что известно о документе до разбора
+type Probe struct {
+	ContentType string
+	Root        xml.Name
+	IsJSON      bool
+}
+
+type Options struct {
+	// Strict=false включает восстанавливающий режим для битых XML-лент
+	Strict bool
+	// Entity дополняет HTML-сущности, известные в нестрогом режиме
+	Entity map[string]string
+	// Promote переносит значения из Extra в именованные поля: ключ Extra -> json-имя поля
+	Promote map[string]string
+	// MaxItems и MaxDepth ограничивают документ, 0 - без ограничения
+	MaxItems int
+	MaxDepth int
+}
+
+type Result struct {
+	Channel  *rss.Channel
+	Warnings []string
+}
+
+type IParser interface {
+	Name() string
+	CanParse(probe Probe) bool
+	// Parse получает документ, уже перекодированный в UTF-8
+	Parse(data []byte, options Options) (*Result, error)
+}
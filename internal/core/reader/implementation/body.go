@@ -0,0 +1,89 @@
+package implementation
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"gafarov/rss-reader/internal/model/feed"
+)
+
+const (
+	DefaultMaxBodySize       = 20 << 20
+	DefaultMaxCompressedSize = 10 << 20
+	DefaultMaxItems          = 10000
+	DefaultMaxDepth          = 128
+)
+
+var errLimitExceeded = errors.New("limit exceeded")
+
+func documentLimits(config feed.Config) feed.DocumentLimits {
+	limits := config.Document
+	if limits.MaxBodySize <= 0 {
+		limits.MaxBodySize = DefaultMaxBodySize
+	}
+	if limits.MaxCompressedSize <= 0 {
+		limits.MaxCompressedSize = DefaultMaxCompressedSize
+	}
+	if limits.MaxItems <= 0 {
+		limits.MaxItems = DefaultMaxItems
+	}
+	if limits.MaxDepth <= 0 {
+		limits.MaxDepth = DefaultMaxDepth
+	}
+	return limits
+}
+
+// limitedReader возвращает errLimitExceeded, как только прочитано больше limit байт
+type limitedReader struct {
+	reader io.Reader
+	limit  int64
+	read   int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.read > l.limit {
+		return 0, errLimitExceeded
+	}
+	if remaining := l.limit + 1 - l.read; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	n, err := l.reader.Read(p)
+	l.read += int64(n)
+	if l.read > l.limit {
+		return n, errLimitExceeded
+	}
+	return n, err
+}
+
+// readBody читает тело ответа с ограничением размера до и после распаковки.
+// Возвращает данные, число байт на проводе и вид превышенного ограничения
+func readBody(response *http.Response, limits feed.DocumentLimits) ([]byte, int64, string, error) {
+	wire := &limitedReader{reader: response.Body, limit: limits.MaxBodySize}
+	compressed := strings.EqualFold(response.Header.Get("Content-Encoding"), "gzip")
+
+	var body io.Reader = wire
+	if compressed {
+		wire.limit = limits.MaxCompressedSize
+		gz, err := gzip.NewReader(wire)
+		if err != nil {
+			if errors.Is(err, errLimitExceeded) {
+				return nil, wire.read, LimitCompressedBody, nil
+			}
+			return nil, wire.read, "", err
+		}
+		defer gz.Close()
+		body = &limitedReader{reader: gz, limit: limits.MaxBodySize}
+	}
+
+	data, err := io.ReadAll(body)
+	if errors.Is(err, errLimitExceeded) {
+		if compressed && wire.read > wire.limit {
+			return nil, wire.read, LimitCompressedBody, nil
+		}
+		return nil, wire.read, LimitBody, nil
+	}
+	return data, wire.read, "", err
+}
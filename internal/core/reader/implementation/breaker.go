@@ -0,0 +1,187 @@
+package implementation
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"gafarov/rss-reader/internal/model/feed"
+
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultFailureThreshold = 5
+	DefaultOpenTimeout      = 5 * time.Minute
+)
+
+type BreakerState int
+
+const (
+	StateClosed BreakerState = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// QuarantinedFeed - лента, отключенная после ошибок подряд
+type QuarantinedFeed struct {
+	URL       string
+	State     BreakerState
+	Failures  int
+	Since     time.Time
+	LastError string
+}
+
+type breaker struct {
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	since    time.Time
+	lastErr  error
+}
+
+func breakerPolicy(config feed.Config) feed.BreakerPolicy {
+	policy := config.Breaker
+	if policy.FailureThreshold <= 0 {
+		policy.FailureThreshold = DefaultFailureThreshold
+	}
+	if policy.OpenTimeout <= 0 {
+		policy.OpenTimeout = DefaultOpenTimeout
+	}
+	return policy
+}
+
+func (r *RssReader) breakerFor(url string) *breaker {
+	b, ok := r.breakers[url]
+	if !ok {
+		b = &breaker{}
+		r.breakers[url] = b
+	}
+	return b
+}
+
+// allowRequest сообщает, можно ли опрашивать ленту. Отключенная лента
+// после OpenTimeout переходит в half-open и получает один пробный запрос
+func (r *RssReader) allowRequest(url string) bool {
+	policy := breakerPolicy(r.feedConfig(url))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b := r.breakerFor(url)
+	switch b.state {
+	case StateOpen:
+		if time.Since(b.openedAt) < policy.OpenTimeout {
+			return false
+		}
+		r.setBreakerState(url, b, StateHalfOpen)
+		return true
+	default:
+		return true
+	}
+}
+
+// recordResult учитывает результат опроса и возвращает true, если лента
+// была в рабочем состоянии (ошибку стоит писать в журнал)
+func (r *RssReader) recordResult(url string, err error) bool {
+	policy := breakerPolicy(r.feedConfig(url))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b := r.breakerFor(url)
+	wasClosed := b.state == StateClosed
+
+	if err == nil {
+		b.failures = 0
+		b.lastErr = nil
+		if b.state != StateClosed {
+			r.setBreakerState(url, b, StateClosed)
+		}
+		return wasClosed
+	}
+
+	b.failures++
+	b.lastErr = err
+	switch {
+	case b.state == StateHalfOpen:
+		b.openedAt = time.Now()
+		r.setBreakerState(url, b, StateOpen)
+	case b.state == StateClosed && b.failures >= policy.FailureThreshold:
+		b.openedAt = time.Now()
+		b.since = b.openedAt
+		r.setBreakerState(url, b, StateOpen)
+	}
+	return wasClosed
+}
+
+func (r *RssReader) setBreakerState(url string, b *breaker, state BreakerState) {
+	previous := b.state
+	b.state = state
+	if r.logger == nil {
+		return
+	}
+
+	fields := []zap.Field{
+		zap.String("url", feed.RedactURL(url)),
+		zap.String("from", previous.String()),
+		zap.String("to", state.String()),
+		zap.Int("failures", b.failures),
+	}
+	switch {
+	case previous == StateClosed && state == StateOpen:
+		r.logger.Error("feed quarantined", append(fields, zap.Error(b.lastErr))...)
+	case state == StateClosed:
+		r.logger.Info("feed restored", fields...)
+	default:
+		r.logger.Info("feed breaker state changed", fields...)
+	}
+}
+
+// Quarantined возвращает ленты, отключенные после ошибок подряд
+func (r *RssReader) Quarantined() []QuarantinedFeed {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var result []QuarantinedFeed
+	for url, b := range r.breakers {
+		if b.state == StateClosed {
+			continue
+		}
+		feed := QuarantinedFeed{URL: url, State: b.state, Failures: b.failures, Since: b.since}
+		if b.lastErr != nil {
+			feed.LastError = b.lastErr.Error()
+		}
+		result = append(result, feed)
+	}
+	slices.SortFunc(result, func(a, b QuarantinedFeed) int {
+		return strings.Compare(a.URL, b.URL)
+	})
+	return result
+}
+
+// Unquarantine возвращает ленту в работу, не дожидаясь пробного запроса
+func (r *RssReader) Unquarantine(url string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, ok := r.breakers[url]
+	if !ok || b.state == StateClosed {
+		return false
+	}
+	b.failures = 0
+	b.lastErr = nil
+	r.setBreakerState(url, b, StateClosed)
+	return true
+}
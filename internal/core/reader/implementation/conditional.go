@@ -0,0 +1,116 @@
+package implementation
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	model "gafarov/rss-reader/internal/model/cache"
+
+	"go.uber.org/zap"
+)
+
+const (
+	ValidatorsKey = "rss_reader:validators:"
+)
+
+// Stats - счетчики запросов к лентам
+type Stats struct {
+	Requests      int64
+	NotModified   int64
+	BytesReceived int64
+	BytesSaved    int64
+}
+
+type stats struct {
+	requests      atomic.Int64
+	notModified   atomic.Int64
+	bytesReceived atomic.Int64
+	bytesSaved    atomic.Int64
+}
+
+func (r *RssReader) Stats() Stats {
+	return Stats{
+		Requests:      r.stats.requests.Load(),
+		NotModified:   r.stats.notModified.Load(),
+		BytesReceived: r.stats.bytesReceived.Load(),
+		BytesSaved:    r.stats.bytesSaved.Load(),
+	}
+}
+
+func (r *RssReader) readValidators(url string) *model.Validators {
+	if r.cache == nil {
+		return nil
+	}
+
+	data, err := r.cache.Get(ValidatorsKey + url)
+	if err != nil {
+		if r.logger != nil {
+			r.logger.Error("failed to get validators", zap.Error(err))
+		}
+		return nil
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	validators := &model.Validators{}
+	if err := json.Unmarshal(data, validators); err != nil {
+		return nil
+	}
+	return validators
+}
+
+// newValidators возвращает nil, если ответ нельзя проверить условным запросом
+func newValidators(header http.Header, length int64) *model.Validators {
+	validators := &model.Validators{
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+		Length:       length,
+	}
+	if validators.ETag == "" && validators.LastModified == "" {
+		return nil
+	}
+	return validators
+}
+
+func (r *RssReader) saveValidators(url string, validators *model.Validators) {
+	if r.cache == nil || validators == nil {
+		return
+	}
+
+	data, err := json.Marshal(validators)
+	if err != nil {
+		return
+	}
+	if err := r.cache.Set(ValidatorsKey+url, data, 14*24*time.Hour); err != nil && r.logger != nil {
+		r.logger.Error("failed to save validators", zap.Error(err))
+	}
+}
+
+// commitValidators сохраняет валидаторы, отложенные при fetchDeferred.
+// При discard они отбрасываются, и следующий опрос получит документ целиком
+func (r *RssReader) commitValidators(url string, discard bool) {
+	r.mu.Lock()
+	validators := r.pending[url]
+	delete(r.pending, url)
+	r.mu.Unlock()
+
+	if !discard {
+		r.saveValidators(url, validators)
+	}
+}
+
+// setConditional добавляет If-None-Match/If-Modified-Since, если есть прочитанная копия ленты
+func setConditional(req *http.Request, validators *model.Validators) {
+	if validators == nil {
+		return
+	}
+	if validators.ETag != "" {
+		req.Header.Set("If-None-Match", validators.ETag)
+	}
+	if validators.LastModified != "" {
+		req.Header.Set("If-Modified-Since", validators.LastModified)
+	}
+}
@@ -0,0 +1,195 @@
+package implementation
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var dateLayouts = []string{
+	"2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04 -0700",
+	"2 Jan 06 15:04:05 -0700",
+	"2 Jan 06 15:04 -0700",
+	"2 Jan 2006 15:04:05",
+	"2 Jan 2006 15:04",
+	"2 Jan 2006 3:04:05 PM -0700",
+	"2 Jan 2006 3:04 PM -0700",
+	"2 Jan 2006 3:04:05 PM",
+	"2 Jan 2006 3:04 PM",
+	"2 Jan 2006, 15:04:05 -0700",
+	"2 Jan 2006, 15:04 -0700",
+	"2 Jan 2006, 15:04:05",
+	"2 Jan 2006, 15:04",
+	"2 Jan 2006",
+	"2-Jan-2006 15:04:05 -0700",
+	"2-Jan-06 15:04:05 -0700",
+	"Jan 2, 2006 15:04:05 -0700",
+	"Jan 2, 2006 15:04:05",
+	"Jan 2, 2006 15:04",
+	"Jan 2, 2006 3:04:05 PM -0700",
+	"Jan 2, 2006 3:04 PM -0700",
+	"Jan 2, 2006 3:04:05 PM",
+	"Jan 2, 2006 3:04 PM",
+	"Jan 2, 2006",
+	"Jan _2 15:04:05 -0700 2006",
+	"Jan _2 15:04:05 2006",
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05-0700",
+	"2006-01-02T15:04:05.999999999-0700",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05.999999999 -0700",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"2006/01/02",
+	"2.1.2006 15:04:05 -0700",
+	"2.1.2006 15:04:05",
+	"2.1.2006 15:04",
+	"2.1.2006, 15:04",
+	"2.1.2006",
+	"2.1.06 15:04",
+	"15:04 2.1.2006",
+	"15:04, 2.1.2006",
+	"15:04 2 Jan 2006",
+	"15:04, 2 Jan 2006",
+	"20060102T150405Z0700",
+	"20060102150405",
+}
+
+// Названия месяцев приводятся к английским сокращениям, чтобы обойтись одним набором шаблонов
+var monthNames = map[string]string{
+	"январь": "Jan", "января": "Jan", "янв": "Jan", "january": "Jan", "jan": "Jan",
+	"февраль": "Feb", "февраля": "Feb", "фев": "Feb", "февр": "Feb", "february": "Feb", "feb": "Feb",
+	"март": "Mar", "марта": "Mar", "мар": "Mar", "march": "Mar", "mar": "Mar",
+	"апрель": "Apr", "апреля": "Apr", "апр": "Apr", "april": "Apr", "apr": "Apr",
+	"май": "May", "мая": "May", "may": "May",
+	"июнь": "Jun", "июня": "Jun", "июн": "Jun", "june": "Jun", "jun": "Jun",
+	"июль": "Jul", "июля": "Jul", "июл": "Jul", "july": "Jul", "jul": "Jul",
+	"август": "Aug", "августа": "Aug", "авг": "Aug", "august": "Aug", "aug": "Aug",
+	"сентябрь": "Sep", "сентября": "Sep", "сен": "Sep", "сент": "Sep", "september": "Sep", "sep": "Sep", "sept": "Sep",
+	"октябрь": "Oct", "октября": "Oct", "окт": "Oct", "october": "Oct", "oct": "Oct",
+	"ноябрь": "Nov", "ноября": "Nov", "ноя": "Nov", "нояб": "Nov", "november": "Nov", "nov": "Nov",
+	"декабрь": "Dec", "декабря": "Dec", "дек": "Dec", "december": "Dec", "dec": "Dec",
+}
+
+var weekdayNames = map[string]struct{}{
+	"пн": {}, "вт": {}, "ср": {}, "чт": {}, "пт": {}, "сб": {}, "вс": {},
+	"понедельник": {}, "вторник": {}, "среда": {}, "четверг": {}, "пятница": {}, "суббота": {}, "воскресенье": {},
+	"mon": {}, "tue": {}, "tues": {}, "wed": {}, "thu": {}, "thur": {}, "thurs": {}, "fri": {}, "sat": {}, "sun": {},
+	"monday": {}, "tuesday": {}, "wednesday": {}, "thursday": {}, "friday": {}, "saturday": {}, "sunday": {},
+}
+
+// Смещения сокращенных названий часовых поясов, встречающихся в лентах
+var zoneOffsets = map[string]string{
+	"UT": "+0000", "UTC": "+0000", "GMT": "+0000", "Z": "+0000",
+	"MSK": "+0300", "MSD": "+0400", "МСК": "+0300",
+	"KALT": "+0200", "SAMT": "+0400", "YEKT": "+0500", "OMST": "+0600", "NOVT": "+0700",
+	"KRAT": "+0700", "IRKT": "+0800", "YAKT": "+0900", "VLAT": "+1000", "MAGT": "+1100", "PETT": "+1200",
+	"WET": "+0000", "WEST": "+0100", "BST": "+0100",
+	"CET": "+0100", "CEST": "+0200", "MET": "+0100", "MEST": "+0200",
+	"EET": "+0200", "EEST": "+0300", "TRT": "+0300",
+	"EST": "-0500", "EDT": "-0400", "CST": "-0600", "CDT": "-0500",
+	"MST": "-0700", "MDT": "-0600", "PST": "-0800", "PDT": "-0700",
+	"AKST": "-0900", "AKDT": "-0800", "HST": "-1000",
+	"JST": "+0900", "KST": "+0900", "HKT": "+0800", "SGT": "+0800",
+	"AEST": "+1000", "AEDT": "+1100", "NZST": "+1200", "NZDT": "+1300",
+}
+
+var (
+	wordPattern      = regexp.MustCompile(`\(?([A-Za-zА-Яа-яЁё]+)\.?\)?`)
+	offsetPattern    = regexp.MustCompile(`\d:\d{2}(?::\d{2})?(?:\.\d+)?\s*[+-]\d{2}:?\d{2}\b`)
+	gmtOffsetPattern = regexp.MustCompile(`(?i)\s*(?:GMT|UTC)\s*([+-])(\d{1,2})(?::?(\d{2}))?$`)
+	spacePattern     = regexp.MustCompile(`\s+`)
+	unixPattern      = regexp.MustCompile(`^\d{9,10}$`)
+)
+
+// ParseRSSDate разбирает дату ленты; даты без часового пояса считаются UTC
+func ParseRSSDate(s string) (*time.Time, error) {
+	return ParseDate(s, time.UTC)
+}
+
+// ParseDate разбирает дату в одном из распространенных форматов, в том числе
+// с русскими названиями месяцев и дней недели. Даты без часового пояса
+// интерпретируются в location, результат всегда приводится к UTC
+func ParseDate(s string, location *time.Location) (*time.Time, error) {
+	if location == nil {
+		location = time.UTC
+	}
+
+	value := normalizeDate(s)
+	if value == "" {
+		return nil, fmt.Errorf("cannot parse date: %s", s)
+	}
+
+	if unixPattern.MatchString(value) {
+		seconds, _ := strconv.ParseInt(value, 10, 64)
+		t := time.Unix(seconds, 0).UTC()
+		return &t, nil
+	}
+
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			t = t.UTC()
+			return &t, nil
+		}
+	}
+
+	return nil, fmt.Errorf("cannot parse date: %s", s)
+}
+
+func normalizeDate(s string) string {
+	value := strings.TrimSpace(spacePattern.ReplaceAllString(s, " "))
+
+	// "GMT+3", "UTC+03:00" -> "+0300"
+	if match := gmtOffsetPattern.FindStringSubmatchIndex(value); match != nil {
+		hours, _ := strconv.Atoi(value[match[4]:match[5]])
+		minutes := 0
+		if match[6] >= 0 {
+			minutes, _ = strconv.Atoi(value[match[6]:match[7]])
+		}
+		value = fmt.Sprintf("%s %s%02d%02d", value[:match[0]], value[match[2]:match[3]], hours, minutes)
+	}
+
+	// день недели в начале строки не нужен для разбора
+	if loc := wordPattern.FindStringSubmatchIndex(value); loc != nil && loc[0] == 0 {
+		if _, ok := weekdayNames[strings.ToLower(value[loc[2]:loc[3]])]; ok {
+			value = strings.TrimLeft(value[loc[1]:], " ,")
+		}
+	}
+
+	hasOffset := offsetPattern.MatchString(value)
+	value = wordPattern.ReplaceAllStringFunc(value, func(token string) string {
+		word := wordPattern.FindStringSubmatch(token)[1]
+		if month, ok := monthNames[strings.ToLower(word)]; ok {
+			return month
+		}
+		// "5 октября 2026 г. 14:00"
+		if lower := strings.ToLower(word); lower == "г" || lower == "года" {
+			return ""
+		}
+		// сокращение пояса заменяется смещением, если оно не указано рядом: "+0300 (MSK)"
+		if offset, ok := zoneOffsets[strings.ToUpper(word)]; ok && len(word) > 1 {
+			if hasOffset {
+				return ""
+			}
+			hasOffset = true
+			return " " + offset
+		}
+		return token
+	})
+
+	value = strings.ReplaceAll(spacePattern.ReplaceAllString(value, " "), " ,", ",")
+	return strings.TrimSpace(value)
+}
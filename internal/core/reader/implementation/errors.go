@@ -1,7 +1,102 @@
 package implementation
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	parsers "gafarov/rss-reader/internal/core/parser/implementation"
+)
 
 var ErrClosed error = errors.New("reader is closed")
 var ErrNoItemsFound error = errors.New("no items found")
 var ErrAlreadyStarted error = errors.New("already started")
+var ErrNotModified error = errors.New("not modified")
+var ErrInvalidConfig error = errors.New("invalid feed config")
+
+// максимальная длина фрагмента тела ответа в тексте ошибки
+const snippetSize = 256
+
+// HTTPStatusError - лента ответила статусом, отличным от 2xx
+type HTTPStatusError struct {
+	URL         string
+	StatusCode  int
+	ContentType string
+	Snippet     string
+	// RetryAfter из заголовка ответа на 429/503
+	RetryAfter time.Duration
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d from %s (%s): %q", e.StatusCode, e.URL, e.ContentType, e.Snippet)
+}
+
+// ParseError - ответ получен, но не разобран как лента
+type ParseError struct {
+	URL         string
+	StatusCode  int
+	ContentType string
+	Snippet     string
+	Err         error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("failed to parse %s (%s): %v: %q", e.URL, e.ContentType, e.Err, e.Snippet)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// TimeoutError - истекло время ожидания ответа
+type TimeoutError struct {
+	URL string
+	Err error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("timeout fetching %s: %v", e.URL, e.Err)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+const (
+	LimitCompressedBody = "compressed body"
+	LimitBody           = "body"
+	LimitItems          = parsers.LimitItems
+	LimitDepth          = parsers.LimitDepth
+)
+
+// TooLargeError - ответ превышает одно из ограничений DocumentLimits
+type TooLargeError struct {
+	URL         string
+	StatusCode  int
+	ContentType string
+	// Kind - LimitCompressedBody, LimitBody, LimitItems или LimitDepth
+	Kind  string
+	Limit int64
+}
+
+func (e *TooLargeError) Error() string {
+	return fmt.Sprintf("response from %s (%s) exceeds %s limit of %d", e.URL, e.ContentType, e.Kind, e.Limit)
+}
+
+func snippet(data []byte) string {
+	if len(data) > snippetSize {
+		data = data[:snippetSize]
+	}
+	return strings.Join(strings.Fields(strings.ToValidUTF8(string(data), "")), " ")
+}
+
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,222 @@
+package implementation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"gafarov/rss-reader/internal/core/fetcher"
+	fetchers "gafarov/rss-reader/internal/core/fetcher/implementation"
+	parsers "gafarov/rss-reader/internal/core/parser/implementation"
+	model "gafarov/rss-reader/internal/model/cache"
+	"gafarov/rss-reader/internal/model/feed"
+	"gafarov/rss-reader/internal/model/rss"
+
+	"go.uber.org/zap"
+)
+
+// fetchMode определяет работу с валидаторами условного запроса
+type fetchMode int
+
+const (
+	// fetchFull - безусловный запрос
+	fetchFull fetchMode = iota
+	// fetchConditional отправляет сохраненные валидаторы и запоминает новые
+	fetchConditional
+	// fetchPeek отправляет валидаторы, но не запоминает новые, чтобы не пропустить
+	// изменения при следующем ParseOnce
+	fetchPeek
+	// fetchDeferred откладывает новые валидаторы до commitValidators: они сохраняются
+	// только после доставки всех элементов, иначе недоставленные элементы скроет 304
+	fetchDeferred
+)
+
+func (r *RssReader) fetchOnce(url string, ctx context.Context, mode fetchMode) (*rss.Channel, error) {
+	feedConfig := r.feedConfig(url)
+	config := feedConfig.HTTP
+	limits := documentLimits(feedConfig)
+	if config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(fetcher.WithQueryKeys(ctx, queryKeys(config)), "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateHTTPConfig(config); err != nil {
+		return nil, err
+	}
+	applyHTTPConfig(req, config)
+	// распаковка выполняется самостоятельно, чтобы ограничить размер сжатого ответа
+	req.Header.Set("Accept-Encoding", "gzip")
+	safeURL := feed.RedactURL(url)
+
+	var validators *model.Validators
+	if mode != fetchFull {
+		validators = r.readValidators(url)
+		setConditional(req, validators)
+	}
+
+	release, err := r.currentLimiter().acquire(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	r.stats.requests.Add(1)
+	response, err := r.doRequest(req, config)
+	if err != nil {
+		err = redactError(err, config)
+		if isTimeout(err) {
+			return nil, &TimeoutError{URL: safeURL, Err: err}
+		}
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	contentType := response.Header.Get("Content-Type")
+
+	if response.StatusCode == http.StatusNotModified && validators != nil {
+		r.stats.notModified.Add(1)
+		r.stats.bytesSaved.Add(validators.Length)
+		return nil, ErrNotModified
+	}
+	// источники без HTTP отвечают 304 и на первый запрос, если документа еще нет
+	if response.StatusCode == http.StatusNotModified && !isHTTP(req.URL.Scheme) {
+		return nil, ErrNoItemsFound
+	}
+
+	data, wireSize, exceeded, err := readBody(response, limits)
+	r.stats.bytesReceived.Add(wireSize)
+	if err != nil {
+		if isTimeout(err) {
+			return nil, &TimeoutError{URL: safeURL, Err: err}
+		}
+		return nil, err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, &HTTPStatusError{
+			URL:         safeURL,
+			StatusCode:  response.StatusCode,
+			ContentType: contentType,
+			Snippet:     snippet(data),
+			RetryAfter:  parseRetryAfter(response.Header.Get("Retry-After")),
+		}
+	}
+
+	// источник без HTTP отдает следующий документ только после сохранения курсора,
+	// поэтому битый или слишком большой документ пропускается, иначе он заблокирует ленту
+	skipDocument := func() {
+		if !isHTTP(req.URL.Scheme) && (mode == fetchConditional || mode == fetchDeferred) {
+			r.saveValidators(url, newValidators(response.Header, wireSize))
+		}
+	}
+
+	switch exceeded {
+	case LimitCompressedBody:
+		skipDocument()
+		return nil, &TooLargeError{URL: safeURL, StatusCode: response.StatusCode, ContentType: contentType,
+			Kind: exceeded, Limit: limits.MaxCompressedSize}
+	case LimitBody:
+		skipDocument()
+		return nil, &TooLargeError{URL: safeURL, StatusCode: response.StatusCode, ContentType: contentType,
+			Kind: exceeded, Limit: limits.MaxBodySize}
+	}
+
+	options := r.parserOptions
+	options.MaxItems = limits.MaxItems
+	options.MaxDepth = limits.MaxDepth
+
+	result, err := r.parsers.Parse(data, contentType, options)
+	var limitErr *parsers.LimitError
+	if errors.As(err, &limitErr) {
+		skipDocument()
+		return nil, &TooLargeError{URL: safeURL, StatusCode: response.StatusCode, ContentType: contentType,
+			Kind: limitErr.Kind, Limit: int64(limitErr.Limit)}
+	} else if err != nil {
+		skipDocument()
+		return nil, &ParseError{URL: safeURL, StatusCode: response.StatusCode, ContentType: contentType, Snippet: snippet(data), Err: err}
+	}
+
+	if len(result.Warnings) > 0 && r.logger != nil {
+		r.logger.Warn("feed recovered with warnings", zap.String("url", feed.RedactURL(url)), zap.Strings("warnings", result.Warnings))
+	}
+
+	if moved := permanentRedirect(response, config); moved != "" && moved != url {
+		if sameOrigin(url, moved) {
+			r.feedMoved(url, moved)
+			url = moved
+		} else {
+			r.foreignMove(url, moved)
+		}
+	}
+
+	switch mode {
+	case fetchConditional:
+		r.saveValidators(url, newValidators(response.Header, wireSize))
+	case fetchDeferred:
+		r.mu.Lock()
+		r.pending[url] = newValidators(response.Header, wireSize)
+		r.mu.Unlock()
+	}
+	r.mu.Lock()
+	r.channels[url] = result.Channel
+	r.mu.Unlock()
+
+	return result.Channel, nil
+}
+
+func isHTTP(scheme string) bool {
+	scheme = strings.ToLower(scheme)
+	return scheme == "http" || scheme == "https"
+}
+
+// fetcherFor возвращает источник, зарегистрированный для схемы, или HTTP-клиент ленты
+func (r *RssReader) fetcherFor(scheme string, client *http.Client) fetcher.IFetcher {
+	r.mu.Lock()
+	source := r.fetchers[strings.ToLower(scheme)]
+	wrap := r.wrapFetcher
+	r.mu.Unlock()
+
+	if source == nil {
+		source = fetchers.NewHTTP(client)
+	}
+	if wrap != nil {
+		source = wrap(source)
+	}
+	return source
+}
+
+// logFetchError пишет ошибку чтения ленты с полями, зависящими от ее типа
+func (r *RssReader) logFetchError(msg, url string, err error) {
+	if r.logger == nil {
+		return
+	}
+
+	var (
+		statusErr   *HTTPStatusError
+		parseErr    *ParseError
+		timeoutErr  *TimeoutError
+		tooLargeErr *TooLargeError
+	)
+
+	switch {
+	case errors.As(err, &statusErr):
+		r.logger.Warn(msg+": unexpected status", zap.String("url", feed.RedactURL(url)), zap.Int("status", statusErr.StatusCode),
+			zap.String("contentType", statusErr.ContentType), zap.String("snippet", statusErr.Snippet))
+	case errors.As(err, &timeoutErr):
+		r.logger.Warn(msg+": timeout", zap.String("url", feed.RedactURL(url)), zap.Error(timeoutErr.Err))
+	case errors.As(err, &tooLargeErr):
+		r.logger.Error(msg+": response too large", zap.String("url", feed.RedactURL(url)),
+			zap.String("contentType", tooLargeErr.ContentType), zap.String("kind", tooLargeErr.Kind), zap.Int64("limit", tooLargeErr.Limit))
+	case errors.As(err, &parseErr):
+		r.logger.Error(msg+": invalid feed", zap.String("url", feed.RedactURL(url)), zap.Int("status", parseErr.StatusCode),
+			zap.String("contentType", parseErr.ContentType), zap.String("snippet", parseErr.Snippet), zap.Error(parseErr.Err))
+	default:
+		r.logger.Error(msg, zap.String("url", feed.RedactURL(url)), zap.Error(err))
+	}
+}
@@ -0,0 +1,59 @@
+package implementation
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"slices"
+	"strings"
+
+	model "gafarov/rss-reader/internal/model/cache"
+	"gafarov/rss-reader/internal/model/rss"
+)
+
+// Поля, правка которых считается изменением материала. Имена совпадают с json-тегами rss.Item
+func fingerprintFields(item *rss.Item) map[string]string {
+	return map[string]string{
+		"title":       item.Title,
+		"link":        item.Link,
+		"description": item.Description,
+		"fullText":    item.Fulltext,
+		"author":      item.Author,
+		"category":    strings.Join(item.Category, "\n"),
+	}
+}
+
+func hashString(s string) string {
+	sum := sha1.Sum([]byte(strings.TrimSpace(s)))
+	return hex.EncodeToString(sum[:])
+}
+
+func fingerprint(item *rss.Item) model.Fingerprint {
+	fields := fingerprintFields(item)
+	result := model.Fingerprint{Fields: make(map[string]string, len(fields))}
+
+	names := make([]string, 0, len(fields))
+	for name, value := range fields {
+		result.Fields[name] = hashString(value)
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	h := sha1.New()
+	for _, name := range names {
+		h.Write([]byte(name + ":" + result.Fields[name] + "\n"))
+	}
+	result.Hash = hex.EncodeToString(h.Sum(nil))
+	return result
+}
+
+// changedFields возвращает отсортированные имена полей, отпечатки которых различаются
+func changedFields(previous, current model.Fingerprint) []string {
+	var changed []string
+	for name, hash := range current.Fields {
+		if previous.Fields[name] != hash {
+			changed = append(changed, name)
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
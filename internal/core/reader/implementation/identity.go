@@ -0,0 +1,104 @@
+package implementation
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"net/url"
+	"sort"
+	"strings"
+
+	"gafarov/rss-reader/internal/model/feed"
+	"gafarov/rss-reader/internal/model/rss"
+)
+
+// Параметры, которые не влияют на материал и отличаются от выгрузки к выгрузке
+var trackingParams = map[string]struct{}{
+	"fbclid": {}, "gclid": {}, "yclid": {}, "ysclid": {}, "_openstat": {}, "from": {}, "ref": {},
+}
+
+// ItemID строит стабильный идентификатор элемента. Если выбранная стратегия не дает
+// значения, используются следующие по надежности: guid, ссылка, хэш содержимого
+func ItemID(item *rss.Item, strategy feed.IdentityStrategy) string {
+	guid := strings.TrimSpace(item.Guid.Value)
+	link := strings.TrimSpace(item.Link)
+	if link == "" && item.Guid.PermaLink() && isURL(guid) {
+		link = guid
+	}
+
+	var candidates []string
+	switch strategy {
+	case feed.IdentityLink:
+		candidates = []string{link, guid}
+	case feed.IdentityNormalizedLink:
+		candidates = []string{NormalizeLink(link), guid}
+	case feed.IdentityHash:
+	default:
+		candidates = []string{guid, link}
+	}
+
+	for _, candidate := range candidates {
+		if candidate != "" {
+			return candidate
+		}
+	}
+	return contentHash(item)
+}
+
+func contentHash(item *rss.Item) string {
+	h := sha1.New()
+	h.Write([]byte(strings.TrimSpace(item.Title)))
+	h.Write([]byte{'\n'})
+	h.Write([]byte(NormalizeLink(item.Link)))
+	h.Write([]byte{'\n'})
+	h.Write([]byte(strings.TrimSpace(item.PubDate)))
+	return "sha1:" + hex.EncodeToString(h.Sum(nil))
+}
+
+func isURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// NormalizeLink приводит ссылку к каноническому виду: без схемы, www, фрагмента,
+// метрик и завершающего слэша, с отсортированными параметрами запроса
+func NormalizeLink(link string) string {
+	link = strings.TrimSpace(link)
+	u, err := url.Parse(link)
+	if err != nil || u.Host == "" {
+		return link
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+
+	query := u.Query()
+	for key := range query {
+		lower := strings.ToLower(key)
+		if _, ok := trackingParams[lower]; ok || strings.HasPrefix(lower, "utm_") {
+			query.Del(key)
+		}
+	}
+
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var params []string
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			params = append(params, url.QueryEscape(key)+"="+url.QueryEscape(value))
+		}
+	}
+
+	result := host + strings.TrimRight(u.EscapedPath(), "/")
+	if len(params) > 0 {
+		result += "?" + strings.Join(params, "&")
+	}
+	return result
+}
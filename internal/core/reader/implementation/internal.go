@@ -1,9 +1,13 @@
 package implementation
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
+	model "gafarov/rss-reader/internal/model/cache"
+	"gafarov/rss-reader/internal/model/rss"
+
 	"go.uber.org/zap"
 )
 
@@ -11,13 +15,15 @@ const (
 	LastReadGuidKey = "rss_reader:read_guid:"
 )
 
-func (r *RssReader) isProcessed(id, name string) (bool, error) {
+// readFingerprint возвращает сохраненный отпечаток обработанного элемента.
+// Для отметок старого формата (значение - сам guid) Hash пустой
+func (r *RssReader) readFingerprint(id, name string) (*model.Fingerprint, error) {
 
 	if r.cache == nil {
 		if r.logger != nil {
 			r.logger.Warn("cache is not initialized")
 		}
-		return false, fmt.Errorf("cache is not initialized")
+		return nil, fmt.Errorf("cache is not initialized")
 	}
 
 	data, err := r.cache.Get(LastReadGuidKey + name + ":" + id)
@@ -25,12 +31,21 @@ func (r *RssReader) isProcessed(id, name string) (bool, error) {
 		if r.logger != nil {
 			r.logger.Error("failed to get last read guid", zap.Error(err))
 		}
-		return false, err
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	fingerprint := &model.Fingerprint{}
+	if err := json.Unmarshal(data, fingerprint); err != nil {
+		return &model.Fingerprint{}, nil
 	}
-	return string(data) != "", nil
+	return fingerprint, nil
 }
 
-func (r *RssReader) saveReadGuid(guid, name string, ttl time.Duration) error {
+func (r *RssReader) saveReadGuid(item *rss.Item, name string, ttl time.Duration) error {
 
 	if r.cache == nil {
 		if r.logger != nil {
@@ -39,35 +54,20 @@ func (r *RssReader) saveReadGuid(guid, name string, ttl time.Duration) error {
 		return fmt.Errorf("cache is not initialized")
 	}
 
-	err := r.cache.Set(LastReadGuidKey+name+":"+guid, []byte(guid), ttl)
+	data, err := json.Marshal(fingerprint(item))
+	if err != nil {
+		return err
+	}
+
+	err = r.cache.Set(LastReadGuidKey+name+":"+item.ID, data, ttl)
 	if err != nil {
 		if r.logger != nil {
 			r.logger.Error("failed to save last read guid", zap.Error(err))
 		}
 		return err
 	} else if r.logger != nil {
-		r.logger.Info("last read guid saved", zap.String("guid", guid))
+		r.logger.Info("last read guid saved", zap.String("guid", item.ID))
 	}
 
 	return nil
 }
-
-func ParseRSSDate(s string) (*time.Time, error) {
-	layouts := []string{
-		time.RFC1123Z,
-		time.RFC1123,
-		time.RFC822Z,
-		time.RFC822,
-		time.RFC3339,
-		time.RFC3339Nano,
-		time.UnixDate,
-	}
-
-	for _, layout := range layouts {
-		if t, err := time.Parse(layout, s); err == nil {
-			return &t, nil
-		}
-	}
-
-	return nil, fmt.Errorf("cannot parse date: %s", s)
-}
@@ -0,0 +1,156 @@
+package implementation
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limits ограничивает нагрузку на издателей. Нулевые значения снимают ограничение
+type Limits struct {
+	// PerHostConcurrency - одновременных запросов к одному хосту
+	PerHostConcurrency int
+	// PerHostRate - запросов в секунду к одному хосту
+	PerHostRate float64
+	// GlobalConcurrency - одновременных запросов ко всем лентам
+	GlobalConcurrency int
+}
+
+type hostLimiter struct {
+	slots chan struct{}
+	next  time.Time
+}
+
+// limiter общий для всех лент ридера, ключ - хост ленты
+type limiter struct {
+	mu     sync.Mutex
+	limits Limits
+	global chan struct{}
+	hosts  map[string]*hostLimiter
+}
+
+func newLimiter(limits Limits) *limiter {
+	l := &limiter{limits: limits, hosts: make(map[string]*hostLimiter)}
+	if limits.GlobalConcurrency > 0 {
+		l.global = make(chan struct{}, limits.GlobalConcurrency)
+	}
+	return l
+}
+
+func (l *limiter) host(key string) *hostLimiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	h, ok := l.hosts[key]
+	if !ok {
+		h = &hostLimiter{}
+		if l.limits.PerHostConcurrency > 0 {
+			h.slots = make(chan struct{}, l.limits.PerHostConcurrency)
+		}
+		l.hosts[key] = h
+	}
+	return h
+}
+
+// reserve занимает окно частоты хоста и возвращает время ожидания до него и функцию отмены
+func (l *limiter) reserve(h *hostLimiter) (time.Duration, func()) {
+	if l.limits.PerHostRate <= 0 {
+		return 0, func() {}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if h.next.Before(now) {
+		h.next = now
+	}
+	at := h.next
+	h.next = at.Add(time.Duration(float64(time.Second) / l.limits.PerHostRate))
+	reserved := h.next
+
+	cancel := func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		// окно возвращается, только если после него никто не встал в очередь
+		if h.next.Equal(reserved) {
+			h.next = at
+		}
+	}
+	return at.Sub(now), cancel
+}
+
+// acquire ждет свободного места и возвращает функцию освобождения. Общий слот берется
+// последним, чтобы ожидание окна одного хоста не задерживало запросы к другим
+func (l *limiter) acquire(ctx context.Context, rawURL string) (func(), error) {
+	h := l.host(hostKey(rawURL))
+
+	if err := acquireSlot(ctx, h.slots); err != nil {
+		return nil, err
+	}
+
+	wait, cancel := l.reserve(h)
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			cancel()
+			releaseSlot(h.slots)
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if err := acquireSlot(ctx, l.global); err != nil {
+		cancel()
+		releaseSlot(h.slots)
+		return nil, err
+	}
+
+	return func() {
+		releaseSlot(l.global)
+		releaseSlot(h.slots)
+	}, nil
+}
+
+func acquireSlot(ctx context.Context, slots chan struct{}) error {
+	if slots == nil {
+		return nil
+	}
+	select {
+	case slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func releaseSlot(slots chan struct{}) {
+	if slots != nil {
+		<-slots
+	}
+}
+
+func hostKey(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	return strings.ToLower(u.Host)
+}
+
+// SetLimits задает ограничения для последующих запросов
+func (r *RssReader) SetLimits(limits Limits) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.limiter = newLimiter(limits)
+}
+
+func (r *RssReader) currentLimiter() *limiter {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.limiter
+}
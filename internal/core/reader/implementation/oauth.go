@@ -0,0 +1,223 @@
+package implementation
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	model "gafarov/rss-reader/internal/model/cache"
+	"gafarov/rss-reader/internal/model/feed"
+
+	"go.uber.org/zap"
+)
+
+const (
+	OAuthTokenKey = "rss_reader:oauth_token:"
+	// токен обновляется заранее, чтобы не истечь во время запроса к ленте
+	tokenExpiryDelta = 30 * time.Second
+	defaultTokenTTL  = time.Hour
+	maxTokenBodySize = 1 << 20
+)
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func tokenKey(config *feed.OAuth2Config) string {
+	sum := sha1.Sum([]byte(config.TokenURL + "\n" + config.ClientID + "\n" + strings.Join(config.Scopes, " ")))
+	return OAuthTokenKey + hex.EncodeToString(sum[:])
+}
+
+// tokenLock возвращает блокировку получения токена для одного сервера авторизации и клиента,
+// чтобы медленный сервер токенов не задерживал ленты с другими настройками
+func (r *RssReader) tokenLock(key string) *sync.Mutex {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lock, ok := r.tokenLocks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		r.tokenLocks[key] = lock
+	}
+	return lock
+}
+
+// cachedToken ищет действующий токен в памяти ридера, затем в кэше
+func (r *RssReader) cachedToken(key string) *model.Token {
+	r.mu.Lock()
+	token := r.tokens[key]
+	r.mu.Unlock()
+	if token != nil && time.Until(token.Expiry) > tokenExpiryDelta {
+		return token
+	}
+
+	if r.cache == nil {
+		return nil
+	}
+	data, err := r.cache.Get(key)
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	token = &model.Token{}
+	if err := json.Unmarshal(data, token); err != nil || time.Until(token.Expiry) <= tokenExpiryDelta {
+		return nil
+	}
+
+	r.mu.Lock()
+	r.tokens[key] = token
+	r.mu.Unlock()
+	return token
+}
+
+// accessToken возвращает действующий токен из памяти или кэша или запрашивает новый
+func (r *RssReader) accessToken(ctx context.Context, client *http.Client, config *feed.OAuth2Config, refresh bool) (*model.Token, error) {
+	key := tokenKey(config)
+	lock := r.tokenLock(key)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if !refresh {
+		if token := r.cachedToken(key); token != nil {
+			return token, nil
+		}
+	}
+
+	token, err := r.requestToken(ctx, client, config)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.tokens[key] = token
+	r.mu.Unlock()
+
+	if r.cache != nil {
+		if data, err := json.Marshal(token); err == nil {
+			if err := r.cache.Set(key, data, time.Until(token.Expiry)); err != nil && r.logger != nil {
+				r.logger.Error("failed to save oauth2 token", zap.Error(err))
+			}
+		}
+	}
+	if r.logger != nil {
+		r.logger.Info("oauth2 token obtained", zap.String("tokenUrl", feed.RedactURL(config.TokenURL)),
+			zap.Time("expiry", token.Expiry))
+	}
+	return token, nil
+}
+
+func (r *RssReader) requestToken(ctx context.Context, client *http.Client, config *feed.OAuth2Config) (*model.Token, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(config.Scopes) > 0 {
+		form.Set("scope", strings.Join(config.Scopes, " "))
+	}
+	if config.AuthInParams {
+		form.Set("client_id", config.ClientID)
+		form.Set("client_secret", config.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if !config.AuthInParams {
+		req.SetBasicAuth(url.QueryEscape(config.ClientID), url.QueryEscape(config.ClientSecret))
+	}
+
+	response, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("oauth2 token request: %w", err)
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxTokenBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("oauth2 token request: %w", err)
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, &HTTPStatusError{
+			URL:         feed.RedactURL(config.TokenURL),
+			StatusCode:  response.StatusCode,
+			ContentType: response.Header.Get("Content-Type"),
+			Snippet:     snippet(data),
+			RetryAfter:  parseRetryAfter(response.Header.Get("Retry-After")),
+		}
+	}
+
+	var body tokenResponse
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, fmt.Errorf("oauth2 token response: %w", err)
+	}
+	if body.AccessToken == "" {
+		return nil, fmt.Errorf("oauth2 token response: empty access_token")
+	}
+
+	ttl := defaultTokenTTL
+	if body.ExpiresIn > 0 {
+		ttl = time.Duration(body.ExpiresIn) * time.Second
+	}
+	return &model.Token{
+		AccessToken: body.AccessToken,
+		TokenType:   body.TokenType,
+		Expiry:      time.Now().Add(ttl),
+	}, nil
+}
+
+// authorize добавляет к запросу токен OAuth2, если он настроен для ленты
+func (r *RssReader) authorize(client *http.Client, req *http.Request, config feed.HTTPConfig, refresh bool) error {
+	if config.OAuth2 == nil {
+		return nil
+	}
+
+	token, err := r.accessToken(req.Context(), client, config.OAuth2, refresh)
+	if err != nil {
+		return err
+	}
+
+	// RFC 6750 требует схему Bearer независимо от регистра token_type
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	return nil
+}
+
+// doRequest выполняет запрос и один раз повторяет его со свежим токеном при 401
+func (r *RssReader) doRequest(req *http.Request, config feed.HTTPConfig) (*http.Response, error) {
+	client, err := r.clientFor(config.Transport)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.authorize(client, req, config, false); err != nil {
+		return nil, err
+	}
+
+	source := r.fetcherFor(req.URL.Scheme, client)
+	response, err := source.Fetch(req)
+	if err != nil || response.StatusCode != http.StatusUnauthorized || config.OAuth2 == nil {
+		return response, err
+	}
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, maxTokenBodySize))
+	response.Body.Close()
+
+	if r.logger != nil {
+		r.logger.Warn("feed rejected oauth2 token, refreshing", zap.String("url", feed.RedactURL(req.URL.String(), queryKeys(config)...)))
+	}
+
+	retry := req.Clone(req.Context())
+	if err := r.authorize(client, retry, config, true); err != nil {
+		return nil, err
+	}
+	return source.Fetch(retry)
+}
@@ -2,30 +2,50 @@ package implementation
 
 import (
 	"context"
-	"encoding/xml"
 	"net/http"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"gafarov/rss-reader/internal/core/cache"
+	"gafarov/rss-reader/internal/core/fetcher"
+	"gafarov/rss-reader/internal/core/parser"
+	parsers "gafarov/rss-reader/internal/core/parser/implementation"
+	model "gafarov/rss-reader/internal/model/cache"
+	"gafarov/rss-reader/internal/model/feed"
 	"gafarov/rss-reader/internal/model/rss"
 
 	"go.uber.org/zap"
 )
 
 type RssReader struct {
-	cache     cache.ICache
-	output    chan rss.Item
-	stopOnce  sync.Once
-	stopChan  chan struct{}
-	feeds     map[string]struct{}
-	client    http.Client
-	mu        sync.Mutex
-	wg        sync.WaitGroup
-	isStarted *atomic.Bool
-	isStoped  *atomic.Bool
-	logger    *zap.Logger
+	cache         cache.ICache
+	output        chan rss.Item
+	stopOnce      sync.Once
+	stopChan      chan struct{}
+	feeds         map[string]struct{}
+	configs       map[string]feed.Config
+	channels      map[string]*rss.Channel
+	pending       map[string]*model.Validators
+	breakers      map[string]*breaker
+	moved         map[string]string
+	foreign       map[string]string
+	limiter       *limiter
+	stats         stats
+	client        http.Client
+	clients       map[feed.TransportConfig]*http.Client
+	fetchers      map[string]fetcher.IFetcher
+	wrapFetcher   func(fetcher.IFetcher) fetcher.IFetcher
+	parsers       *parsers.Registry
+	parserOptions parser.Options
+	mu            sync.Mutex
+	tokens        map[string]*model.Token
+	tokenLocks    map[string]*sync.Mutex
+	wg            sync.WaitGroup
+	isStarted     *atomic.Bool
+	isStoped      *atomic.Bool
+	logger        *zap.Logger
 }
 
 func New(cache cache.ICache, logger *zap.Logger) *RssReader {
@@ -37,14 +57,27 @@ func New(cache cache.ICache, logger *zap.Logger) *RssReader {
 	isStarted.Store(false)
 
 	return &RssReader{
-		cache:     cache,
-		output:    make(chan rss.Item, 500),
-		feeds:     make(map[string]struct{}),
-		stopChan:  make(chan struct{}),
-		client:    http.Client{Timeout: 10 * time.Second},
-		isStarted: &isStarted,
-		isStoped:  &isStoped,
-		logger:    logger,
+		cache:         cache,
+		output:        make(chan rss.Item, 500),
+		feeds:         make(map[string]struct{}),
+		configs:       make(map[string]feed.Config),
+		channels:      make(map[string]*rss.Channel),
+		pending:       make(map[string]*model.Validators),
+		breakers:      make(map[string]*breaker),
+		tokens:        make(map[string]*model.Token),
+		tokenLocks:    make(map[string]*sync.Mutex),
+		moved:         make(map[string]string),
+		foreign:       make(map[string]string),
+		limiter:       newLimiter(Limits{}),
+		stopChan:      make(chan struct{}),
+		client:        http.Client{Timeout: DefaultTimeout},
+		clients:       make(map[feed.TransportConfig]*http.Client),
+		fetchers:      make(map[string]fetcher.IFetcher),
+		parsers:       parsers.New(),
+		parserOptions: parser.Options{Strict: true},
+		isStarted:     &isStarted,
+		isStoped:      &isStoped,
+		logger:        logger,
 	}
 }
 
@@ -86,6 +119,18 @@ func (r *RssReader) close() {
 	})
 }
 
+func (r *RssReader) SetFeedConfig(url string, config feed.Config) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.configs[url] = config
+}
+
+func (r *RssReader) feedConfig(url string) feed.Config {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.configs[url]
+}
+
 func (r *RssReader) isInProcessOrRegister(url string) bool {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -106,28 +151,29 @@ func (r *RssReader) StartParsing(url, name string, delay time.Duration, ctx cont
 		return ErrClosed
 	}
 
+	url = r.resolveURL(url)
+
 	if r.isInProcessOrRegister(url) {
 		if r.logger != nil {
-			r.logger.Error("already parsing", zap.String("url", url))
+			r.logger.Error("already parsing", zap.String("url", feed.RedactURL(url)))
 		}
 		return ErrAlreadyStarted
 	} else if r.logger != nil {
-		r.logger.Info("starting parsing", zap.String("url", url))
+		r.logger.Info("starting parsing", zap.String("url", feed.RedactURL(url)))
 	}
 
 	err := r.startOnce(url, name, ctx)
 	if err != nil && err != ErrNoItemsFound {
-		if r.logger != nil {
-			r.logger.Error("failed to start parsing", zap.String("url", url), zap.Error(err))
-		}
+		r.logFetchError("failed to start parsing", url, err)
 		return err
 	}
 
 	r.wg.Add(1)
 	go func(url string, delay time.Duration, ctx context.Context) {
 		defer r.wg.Done()
-		ticker := time.NewTicker(delay)
-		defer ticker.Stop()
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		failures := 0
 
 		for {
 			select {
@@ -136,7 +182,7 @@ func (r *RssReader) StartParsing(url, name string, delay time.Duration, ctx cont
 				delete(r.feeds, url)
 				r.mu.Unlock()
 				if r.logger != nil {
-					r.logger.Info("parsing stopped", zap.String("url", url))
+					r.logger.Info("parsing stopped", zap.String("url", feed.RedactURL(url)))
 				}
 				return
 			case <-r.stopChan:
@@ -144,13 +190,37 @@ func (r *RssReader) StartParsing(url, name string, delay time.Duration, ctx cont
 					r.logger.Info("reader stopped")
 				}
 				return
-			case <-ticker.C:
+			case <-timer.C:
+				url = r.resolveURL(url)
+				if !r.allowRequest(url) {
+					timer.Reset(delay)
+					continue
+				}
+
 				err := r.startOnce(url, name, ctx)
-				if err != nil && err != ErrNoItemsFound {
-					if r.logger != nil {
-						r.logger.Error("failed to parsing", zap.String("url", url), zap.Error(err))
+				if err == ErrNoItemsFound {
+					err = nil
+				}
+				// пока лента отключена, ошибки не пишутся в журнал на каждом опросе
+				verbose := r.recordResult(url, err)
+				if verbose && err != nil {
+					r.logFetchError("failed to parsing", url, err)
+				}
+
+				if err != nil {
+					failures++
+				} else {
+					if failures > 0 && r.logger != nil {
+						r.logger.Info("feed recovered", zap.String("url", feed.RedactURL(url)), zap.Int("failures", failures))
 					}
+					failures = 0
+				}
+
+				next := pollInterval(delay, failures, r.feedConfig(url), err)
+				if verbose && failures > 0 && r.logger != nil {
+					r.logger.Warn("polling backed off", zap.String("url", feed.RedactURL(url)), zap.Int("failures", failures), zap.Duration("next", next))
 				}
+				timer.Reset(next)
 			}
 		}
 	}(url, delay, ctx)
@@ -158,83 +228,117 @@ func (r *RssReader) StartParsing(url, name string, delay time.Duration, ctx cont
 }
 
 func (r *RssReader) startOnce(url, name string, ctx context.Context) error {
-	items, err := r.ParseOnce(url, ctx)
-	if err == ErrNoItemsFound {
+	items, err := r.parseOnce(url, ctx, fetchDeferred)
+	if err == ErrNotModified {
+		// валидаторы сохранены до перезапуска, значит лента уже размечена
+		r.isStarted.Store(true)
+		return nil
+	} else if err == ErrNoItemsFound {
+		r.commitValidators(r.resolveURL(url), false)
 		return nil
 	} else if err != nil {
 		return err
 	}
 
-	wg := sync.WaitGroup{}
-	defer wg.Wait()
-
-	if !r.isStarted.Load() {
+	if !r.isStarted.Load() && !r.feedConfig(url).EmitExisting {
+		wg := sync.WaitGroup{}
 		for _, item := range items {
 			wg.Go(func() {
-				if err := r.saveReadGuid(item.Guid, name, 3*24*time.Hour); err != nil {
+				if err := r.saveReadGuid(item, name, 3*24*time.Hour); err != nil {
 					if r.logger != nil {
 						r.logger.Error("failed to save last read guid", zap.Error(err))
 					}
 				}
 			})
 		}
+		// первичная разметка должна закончиться до проверки, иначе элементы уйдут повторно
+		wg.Wait()
 		r.isStarted.Store(true)
 	}
 
+	dropped := 0
 	for _, item := range items {
 
-		if isProcessed, err := r.isProcessed(item.Guid, name); err != nil {
+		if previous, err := r.readFingerprint(item.ID, name); err != nil {
 			if r.logger != nil {
 				r.logger.Error("failed to check if item is processed", zap.Error(err))
 			}
-		} else if isProcessed {
-			continue
+		} else if previous != nil {
+			if previous.Hash == item.ContentHash {
+				continue
+			}
+
+			// отметка старого формата: запоминаем отпечаток без повторной отправки
+			if previous.Hash == "" {
+				_ = r.saveReadGuid(item, name, 14*24*time.Hour)
+				continue
+			}
+
+			item.Update = &rss.Update{
+				PreviousHash:  previous.Hash,
+				ChangedFields: changedFields(*previous, fingerprint(item)),
+			}
+			if r.logger != nil {
+				r.logger.Info("item updated", zap.String("id", item.ID), zap.Strings("fields", item.Update.ChangedFields))
+			}
 		}
 
 		select {
 		case r.output <- *item:
-			if err := r.saveReadGuid(item.Guid, name, 14*24*time.Hour); err != nil {
+			if err := r.saveReadGuid(item, name, 14*24*time.Hour); err != nil {
 				if r.logger != nil {
 					r.logger.Error("failed to save last read guid", zap.Error(err))
 				}
 			}
 		default:
-			continue
+			dropped++
 		}
 	}
 
+	dropped += r.checkRetractions(items, name)
+
+	// недоставленные элементы должны прийти при следующем опросе, а не потеряться за 304
+	r.commitValidators(r.resolveURL(url), dropped > 0)
+	if dropped > 0 && r.logger != nil {
+		r.logger.Warn("output is full, items will be retried", zap.String("url", feed.RedactURL(url)), zap.Int("dropped", dropped))
+	}
+
 	return nil
 }
 
 func (r *RssReader) ParseOnce(url string, ctx context.Context) ([]*rss.Item, error) {
-	if err := ctx.Err(); err != nil {
-		return nil, err
+	items, err := r.parseOnce(url, ctx, fetchConditional)
+	if err == ErrNotModified {
+		return nil, ErrNoItemsFound
 	}
+	return items, err
+}
 
-	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
-	response, err := r.client.Do(req)
-	if err != nil {
+func (r *RssReader) parseOnce(url string, ctx context.Context, mode fetchMode) ([]*rss.Item, error) {
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
-	defer response.Body.Close()
 
-	var channel rss.Rss
-	if err := xml.NewDecoder(response.Body).Decode(&channel); err != nil {
+	channel, err := r.fetchChannel(url, ctx, mode)
+	if err != nil {
 		return nil, err
 	}
 
+	config := r.feedConfig(url)
 	var items []*rss.Item
 
-	if len(channel.Channel.Items) == 0 {
+	if len(channel.Items) == 0 {
 		return nil, ErrNoItemsFound
 	}
 
-	for i := range channel.Channel.Items {
-		itm := &channel.Channel.Items[i]
-		date, parseErr := ParseRSSDate(itm.PubDate)
+	for i := range channel.Items {
+		itm := &channel.Items[i]
+		date, parseErr := ParseDate(itm.PubDate, config.Location)
 		if parseErr == nil {
 			itm.PubTimeParsed = date
 		}
+		itm.ID = ItemID(itm, config.Identity)
+		itm.ContentHash = fingerprint(itm).Hash
 		items = append(items, itm)
 	}
 
@@ -246,22 +350,49 @@ func (r *RssReader) GetChannel(url string, ctx context.Context) (*rss.Channel, e
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, err
+	url = r.resolveURL(url)
+	r.mu.Lock()
+	cached := r.channels[url]
+	r.mu.Unlock()
+
+	// без сохраненной копии ответ 304 нечем заменить, поэтому запрос безусловный
+	mode := fetchFull
+	if cached != nil {
+		mode = fetchPeek
 	}
-	response, err := r.client.Do(req)
-	if err != nil {
+	channel, err := r.fetchChannel(url, ctx, mode)
+	if err == ErrNotModified && cached != nil {
+		channel = cached
+	} else if err != nil {
 		return nil, err
 	}
-	defer response.Body.Close()
 
-	var channel rss.Rss
-	if err := xml.NewDecoder(response.Body).Decode(&channel); err != nil {
-		return nil, err
-	}
+	result := *channel
+	result.Items = nil
+
+	return &result, nil
+}
+
+func (r *RssReader) RegisterParser(p parser.IParser) {
+	r.parsers.Register(p)
+}
 
-	channel.Channel.Items = nil
+// RegisterFetcher задает источник для схемы адреса ленты, заменяя прежний.
+// Для http и https по умолчанию используется HTTP-клиент с настройками транспорта ленты,
+// остальные источники (file, dir, stdin) подключаются явно
+func (r *RssReader) RegisterFetcher(scheme string, f fetcher.IFetcher) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fetchers[strings.ToLower(scheme)] = f
+}
+
+// WrapFetcher оборачивает все источники, например для записи ответов Recorder.Wrap
+func (r *RssReader) WrapFetcher(wrap func(fetcher.IFetcher) fetcher.IFetcher) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.wrapFetcher = wrap
+}
 
-	return &channel.Channel, nil
+func (r *RssReader) SetParserOptions(options parser.Options) {
+	r.parserOptions = options
 }
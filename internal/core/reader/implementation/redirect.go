@@ -0,0 +1,154 @@
+package implementation
+
+import (
+	"maps"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"time"
+
+	"gafarov/rss-reader/internal/model/feed"
+
+	"go.uber.org/zap"
+)
+
+const (
+	MovedKey = "rss_reader:moved:"
+	// ограничение длины цепочки переездов на случай цикла в кэше
+	maxMoves = 5
+)
+
+// permanentRedirect возвращает конечный адрес, если все перенаправления
+// на пути к ответу были постоянными (301/308)
+func permanentRedirect(response *http.Response, config feed.HTTPConfig) string {
+	req := response.Request
+	if req == nil || req.Response == nil {
+		return ""
+	}
+
+	for hop := req; hop.Response != nil; hop = hop.Response.Request {
+		switch hop.Response.StatusCode {
+		case http.StatusMovedPermanently, http.StatusPermanentRedirect:
+		default:
+			return ""
+		}
+	}
+
+	// параметры из конфигурации добавляются к каждому запросу и не входят в адрес ленты
+	target := *req.URL
+	if len(config.Query) > 0 {
+		query := target.Query()
+		for name := range config.Query {
+			query.Del(name)
+		}
+		target.RawQuery = query.Encode()
+	}
+	return target.String()
+}
+
+// sameOrigin - схема и хост совпадают. Только на такой адрес опрос переключается сам:
+// настройки ленты содержат учетные данные, которые нельзя отправлять другому хосту
+func sameOrigin(from, to string) bool {
+	a, err := neturl.Parse(from)
+	if err != nil {
+		return false
+	}
+	b, err := neturl.Parse(to)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(a.Scheme, b.Scheme) && strings.EqualFold(a.Host, b.Host)
+}
+
+// resolveURL возвращает текущий адрес ленты с учетом сохраненных переездов
+func (r *RssReader) resolveURL(url string) string {
+	r.mu.Lock()
+	moved, ok := r.moved[url]
+	r.mu.Unlock()
+	if ok {
+		return moved
+	}
+
+	if r.cache == nil {
+		return url
+	}
+
+	current := url
+	for range maxMoves {
+		data, err := r.cache.Get(MovedKey + current)
+		if err != nil || len(data) == 0 || string(data) == current || !sameOrigin(current, string(data)) {
+			break
+		}
+		current = string(data)
+	}
+
+	if current != url {
+		r.mu.Lock()
+		r.moved[url] = current
+		if _, ok := r.configs[current]; !ok {
+			if config, ok := r.configs[url]; ok {
+				r.configs[current] = config
+			}
+		}
+		r.mu.Unlock()
+
+		if r.logger != nil {
+			r.logger.Warn("feed moved earlier, update the configured url",
+				zap.String("from", feed.RedactURL(url)), zap.String("to", feed.RedactURL(current)))
+		}
+	}
+	return current
+}
+
+// foreignMove сообщает о переезде ленты на другой хост один раз: опрос продолжается
+// по настроенному адресу, переключить его должен оператор
+func (r *RssReader) foreignMove(from, to string) {
+	r.mu.Lock()
+	notified := r.foreign[from] == to
+	r.foreign[from] = to
+	r.mu.Unlock()
+
+	if !notified && r.logger != nil {
+		r.logger.Warn("feed moved permanently to another host, update the configured url",
+			zap.String("from", feed.RedactURL(from)), zap.String("to", feed.RedactURL(to)))
+	}
+}
+
+// feedMoved запоминает новый адрес ленты и переключает на него опрос
+func (r *RssReader) feedMoved(from, to string) {
+	if r.cache != nil {
+		if err := r.cache.Set(MovedKey+from, []byte(to), 365*24*time.Hour); err != nil && r.logger != nil {
+			r.logger.Error("failed to save feed move", zap.Error(err))
+		}
+	}
+
+	r.mu.Lock()
+	r.moved[from] = to
+	for old, current := range r.moved {
+		if current == from {
+			r.moved[old] = to
+		}
+	}
+	if _, ok := r.configs[to]; !ok {
+		if config, ok := r.configs[from]; ok {
+			r.configs[to] = config
+		}
+	}
+	if _, ok := r.feeds[from]; ok {
+		delete(r.feeds, from)
+		r.feeds[to] = struct{}{}
+	}
+	r.mu.Unlock()
+
+	if r.logger != nil {
+		r.logger.Warn("feed moved permanently, update the configured url",
+			zap.String("from", feed.RedactURL(from)), zap.String("to", feed.RedactURL(to)))
+	}
+}
+
+// Moved возвращает известные переезды лент: исходный адрес -> текущий
+func (r *RssReader) Moved() map[string]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return maps.Clone(r.moved)
+}
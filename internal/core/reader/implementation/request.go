@@ -0,0 +1,73 @@
+package implementation
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"gafarov/rss-reader/internal/model/feed"
+)
+
+const DefaultUserAgent = "rss-reader/1.0"
+
+// applyHTTPConfig добавляет к запросу заголовки, авторизацию, параметры и cookies ленты
+// validateHTTPConfig проверяет имена заголовков до запроса: ошибка net/http содержит
+// имя целиком, и при склеенном с ним значении секрет попал бы в журнал
+func validateHTTPConfig(config feed.HTTPConfig) error {
+	for name := range config.Headers {
+		if !feed.IsToken(name) {
+			return fmt.Errorf("%w: invalid header name", ErrInvalidConfig)
+		}
+	}
+	return nil
+}
+
+func applyHTTPConfig(req *http.Request, config feed.HTTPConfig) {
+	userAgent := config.UserAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	for name, value := range config.Headers {
+		if http.CanonicalHeaderKey(name) == "Host" {
+			req.Host = value
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+
+	if config.BasicAuth != nil {
+		req.SetBasicAuth(config.BasicAuth.Username, config.BasicAuth.Password)
+	} else if config.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+config.BearerToken)
+	}
+
+	if len(config.Query) > 0 {
+		query := req.URL.Query()
+		for name, value := range config.Query {
+			query.Set(name, value)
+		}
+		req.URL.RawQuery = query.Encode()
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(config.Cookies)) {
+		req.AddCookie(&http.Cookie{Name: name, Value: config.Cookies[name]})
+	}
+}
+
+// redactError убирает секреты из адреса в сетевых ошибках клиента
+func redactError(err error, config feed.HTTPConfig) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = feed.RedactURL(urlErr.URL, queryKeys(config)...)
+	}
+	return err
+}
+
+func queryKeys(config feed.HTTPConfig) []string {
+	return slices.Collect(maps.Keys(config.Query))
+}
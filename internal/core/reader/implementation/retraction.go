@@ -0,0 +1,134 @@
+package implementation
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	model "gafarov/rss-reader/internal/model/cache"
+	"gafarov/rss-reader/internal/model/rss"
+
+	"go.uber.org/zap"
+)
+
+const (
+	SnapshotKey = "rss_reader:snapshot:"
+)
+
+func (r *RssReader) readSnapshot(name string) ([]model.SnapshotItem, error) {
+	if r.cache == nil {
+		return nil, fmt.Errorf("cache is not initialized")
+	}
+
+	data, err := r.cache.Get(SnapshotKey + name)
+	if err != nil || len(data) == 0 {
+		return nil, err
+	}
+
+	var snapshot []model.SnapshotItem
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+func (r *RssReader) saveSnapshot(items []*rss.Item, name string, ttl time.Duration) error {
+	if r.cache == nil {
+		return fmt.Errorf("cache is not initialized")
+	}
+
+	snapshot := make([]model.SnapshotItem, 0, len(items))
+	for _, item := range items {
+		snapshot = append(snapshot, model.SnapshotItem{
+			ID:      item.ID,
+			Title:   item.Title,
+			Link:    item.Link,
+			PubTime: item.PubTimeParsed,
+		})
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return r.cache.Set(SnapshotKey+name, data, ttl)
+}
+
+// retracted возвращает элементы прошлого чтения, пропавшие из ленты раньше срока.
+// Элемент считается снятым, если он новее самого старого оставшегося элемента,
+// а без дат - если в прошлом чтении после него шел элемент, который все еще в ленте
+func retracted(previous []model.SnapshotItem, items []*rss.Item) []model.SnapshotItem {
+	present := make(map[string]struct{}, len(items))
+	var oldest *time.Time
+	for _, item := range items {
+		present[item.ID] = struct{}{}
+		if item.PubTimeParsed != nil && (oldest == nil || item.PubTimeParsed.Before(*oldest)) {
+			oldest = item.PubTimeParsed
+		}
+	}
+
+	lastPresent := -1
+	for i, item := range previous {
+		if _, ok := present[item.ID]; ok {
+			lastPresent = i
+		}
+	}
+
+	var result []model.SnapshotItem
+	for i, item := range previous {
+		if _, ok := present[item.ID]; ok {
+			continue
+		}
+		if item.PubTime != nil && oldest != nil {
+			if item.PubTime.After(*oldest) {
+				result = append(result, item)
+			}
+		} else if i < lastPresent {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// checkRetractions сравнивает ленту с прошлым чтением, отправляет события о снятых элементах
+// и возвращает число событий, не поместившихся в выходной канал
+func (r *RssReader) checkRetractions(items []*rss.Item, name string) int {
+	previous, err := r.readSnapshot(name)
+	if err != nil && r.logger != nil {
+		r.logger.Error("failed to read feed snapshot", zap.Error(err))
+	}
+
+	dropped := 0
+	for _, removed := range retracted(previous, items) {
+		item := rss.Item{
+			ID:            removed.ID,
+			Title:         removed.Title,
+			Link:          removed.Link,
+			PubTimeParsed: removed.PubTime,
+			Retracted:     true,
+		}
+
+		select {
+		case r.output <- item:
+			if r.logger != nil {
+				r.logger.Info("item retracted", zap.String("id", item.ID), zap.String("link", item.Link))
+			}
+			// если материал вернут в ленту, он будет отправлен заново
+			if err := r.cache.Set(LastReadGuidKey+name+":"+item.ID, []byte{}, time.Minute); err != nil && r.logger != nil {
+				r.logger.Error("failed to reset last read guid", zap.Error(err))
+			}
+		default:
+			dropped++
+		}
+	}
+
+	// прошлый снимок остается, пока события не доставлены: иначе снятые элементы из него
+	// пропадут и при следующем опросе уже не будут обнаружены
+	if dropped > 0 {
+		return dropped
+	}
+	if err := r.saveSnapshot(items, name, 14*24*time.Hour); err != nil && r.logger != nil {
+		r.logger.Error("failed to save feed snapshot", zap.Error(err))
+	}
+	return 0
+}
@@ -0,0 +1,189 @@
+package implementation
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"gafarov/rss-reader/internal/model/feed"
+	"gafarov/rss-reader/internal/model/rss"
+
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultRetryAttempts   = 3
+	DefaultRetryBaseDelay  = 500 * time.Millisecond
+	DefaultRetryMaxDelay   = 30 * time.Second
+	DefaultMaxPollInterval = 10 * time.Minute
+)
+
+func retryPolicy(config feed.Config) feed.RetryPolicy {
+	policy := config.Retry
+	if policy.Attempts <= 0 {
+		policy.Attempts = DefaultRetryAttempts
+	}
+	if policy.BaseDelay <= 0 {
+		policy.BaseDelay = DefaultRetryBaseDelay
+	}
+	if policy.MaxDelay <= 0 {
+		policy.MaxDelay = DefaultRetryMaxDelay
+	}
+	return policy
+}
+
+// fetchChannel читает ленту, повторяя запрос при временных ошибках
+func (r *RssReader) fetchChannel(url string, ctx context.Context, mode fetchMode) (*rss.Channel, error) {
+	url = r.resolveURL(url)
+	policy := retryPolicy(r.feedConfig(url))
+
+	for attempt := 1; ; attempt++ {
+		channel, err := r.fetchOnce(url, ctx, mode)
+		if err == nil || attempt >= policy.Attempts || !isRetryable(err) || ctx.Err() != nil {
+			return channel, err
+		}
+
+		wait := backoff(policy.BaseDelay, policy.MaxDelay, attempt)
+		if retryAfter := retryAfterOf(err); retryAfter > 0 {
+			// ждать дольше MaxDelay внутри чтения нет смысла, это учтет интервал опроса
+			if retryAfter > policy.MaxDelay {
+				return nil, err
+			}
+			wait = retryAfter
+		}
+
+		if r.logger != nil {
+			r.logger.Warn("retrying feed request", zap.String("url", feed.RedactURL(url)), zap.Int("attempt", attempt),
+				zap.Duration("wait", wait), zap.Error(err))
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-r.stopChan:
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+// isRetryable - сетевые ошибки, таймауты, 429 и 5xx, кроме 501. Остальные ошибки,
+// в том числе неизвестные, при повторе не исчезнут
+func isRetryable(err error) bool {
+	var (
+		statusErr  *HTTPStatusError
+		timeoutErr *TimeoutError
+	)
+	switch {
+	case errors.As(err, &statusErr):
+		return statusErr.StatusCode == http.StatusTooManyRequests ||
+			(statusErr.StatusCode >= 500 && statusErr.StatusCode != http.StatusNotImplemented)
+	case errors.As(err, &timeoutErr):
+		return true
+	case errors.Is(err, context.Canceled):
+		return false
+	}
+	return isNetworkError(err)
+}
+
+// isNetworkError отделяет сбои соединения от ошибок, которые net/http оборачивает
+// в *url.Error: сам *url.Error реализует net.Error при любой причине
+func isNetworkError(err error) bool {
+	var urlErr *url.Error
+	for errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+
+	var (
+		certErr      *tls.CertificateVerificationError
+		authorityErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		invalidErr   x509.CertificateInvalidError
+		netErr       net.Error
+	)
+	switch {
+	case errors.As(err, &certErr), errors.As(err, &authorityErr), errors.As(err, &hostnameErr), errors.As(err, &invalidErr):
+		return false
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, syscall.ECONNRESET):
+		return true
+	}
+	return errors.As(err, &netErr)
+}
+
+func retryAfterOf(err error) time.Duration {
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.RetryAfter
+	}
+	return 0
+}
+
+// parseRetryAfter разбирает Retry-After в секундах или в виде HTTP-даты
+func parseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(t); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
+
+// backoff - экспоненциальная задержка со случайным разбросом в пределах [d/2, d]
+func backoff(base, max time.Duration, attempt int) time.Duration {
+	wait := base
+	for i := 1; i < attempt && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		wait = max
+	}
+	half := wait / 2
+	return half + rand.N(half+1)
+}
+
+// pollInterval увеличивает интервал опроса после ошибок подряд и сбрасывает его после успеха
+func pollInterval(delay time.Duration, failures int, config feed.Config, err error) time.Duration {
+	if failures == 0 {
+		return delay
+	}
+
+	max := config.MaxPollInterval
+	if max <= 0 {
+		max = DefaultMaxPollInterval
+	}
+	if max < delay {
+		max = delay
+	}
+
+	wait := backoff(delay, max, failures+1)
+	if wait < delay {
+		wait = delay
+	}
+	if retryAfter := retryAfterOf(err); retryAfter > wait {
+		wait = retryAfter
+	}
+	return wait
+}
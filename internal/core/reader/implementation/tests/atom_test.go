@@ -0,0 +1,83 @@
+package implementation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	rss "gafarov/rss-reader/internal/core/reader/implementation"
+)
+
+func TestRssReader_ParseAtom(t *testing.T) {
+	server := newFixtureServer(t, "atom.xml", "application/atom+xml")
+	r := rss.New(nil, nil)
+	defer r.Stop()
+
+	items, err := r.ParseOnce(server.URL, context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+
+	item := items[0]
+	assert.Equal(t, "Первая новость", item.Title)
+	assert.Equal(t, "urn:uuid:1225c695-cfb8-4ebb-aaaa-80da344efa6a", item.Guid.Value)
+	assert.Equal(t, "https://example.com/news/1", item.Link)
+	assert.Equal(t, "https://example.com/news/1.jpg", item.Enclosure.URL)
+	assert.Equal(t, "image/jpeg", item.Enclosure.Type)
+	assert.Equal(t, "Краткое описание", item.Description)
+	assert.Equal(t, "<p>Полный текст</p>", item.Fulltext)
+	assert.Equal(t, "Иван Петров", item.Author)
+	assert.Equal(t, []string{"politics"}, item.Category)
+	assert.NotNil(t, item.PubTimeParsed)
+	assert.Equal(t, "2026-10-05T13:00:00+03:00", item.PubDate)
+
+	item = items[1]
+	assert.Contains(t, item.Title, "Вторая <b>новость</b>")
+	assert.Equal(t, "https://example.com/news/2", item.Link)
+	assert.Equal(t, "2026-10-05T12:00:00Z", item.PubDate)
+	assert.Equal(t, "Редакция", item.Author)
+	assert.Equal(t, []string{"Экономика"}, item.Category)
+}
+
+func TestRssReader_GetAtomChannel(t *testing.T) {
+	server := newFixtureServer(t, "atom.xml", "application/atom+xml")
+	r := rss.New(nil, nil)
+	defer r.Stop()
+
+	channel, err := r.GetChannel(server.URL, context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "Пример Atom", channel.Title)
+	assert.Equal(t, "Новости партнера", channel.Description)
+	assert.Equal(t, "https://example.com/", channel.Link)
+	assert.Equal(t, "ru", channel.Language)
+	assert.Empty(t, channel.Items)
+}
+
+func TestRssReader_AtomIgnoresExtensionElements(t *testing.T) {
+	server := newFixtureServer(t, "atom-namespaced.xml", "application/atom+xml")
+	r := rss.New(nil, nil)
+	defer r.Stop()
+
+	channel, err := r.GetChannel(server.URL, context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "Пример Atom", channel.Title)
+
+	items, err := r.ParseOnce(server.URL, context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, "Первая новость", items[0].Title)
+	assert.Equal(t, "urn:uuid:1225c695-cfb8-4ebb-aaaa-80da344efa6a", items[0].Guid.Value)
+}
+
+func TestRssReader_AtomWithoutNamespace(t *testing.T) {
+	server := newFeedServer(t, `<feed><title>Лента</title><entry><id>1</id><title>Новость</title>`+
+		`<link href="https://example.com/news/1"/><updated>2026-10-05T12:00:00Z</updated></entry></feed>`)
+	r := rss.New(nil, nil)
+	defer r.Stop()
+
+	items, err := r.ParseOnce(server.URL, context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, "Новость", items[0].Title)
+	assert.Equal(t, "1", items[0].Guid.Value)
+}
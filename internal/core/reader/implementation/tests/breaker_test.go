@@ -0,0 +1,97 @@
+package implementation_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	rss "gafarov/rss-reader/internal/core/reader/implementation"
+	"gafarov/rss-reader/internal/model/feed"
+)
+
+func TestRssReader_CircuitBreaker(t *testing.T) {
+	server := newFlakyServer(t, func(request int) (int, http.Header) {
+		return http.StatusOK, nil
+	})
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetFeedConfig(server.URL, feed.Config{
+		Retry:           feed.RetryPolicy{Attempts: 1},
+		MaxPollInterval: 10 * time.Millisecond,
+		Breaker:         feed.BreakerPolicy{FailureThreshold: 3, OpenTimeout: 300 * time.Millisecond},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := r.StartParsing(server.URL, "test", 10*time.Millisecond, ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, r.Quarantined())
+
+	server.SetStatus(func(request int) (int, http.Header) {
+		return http.StatusInternalServerError, nil
+	})
+
+	assert.Eventually(t, func() bool { return len(r.Quarantined()) == 1 }, time.Second, 5*time.Millisecond)
+	quarantined := r.Quarantined()[0]
+	assert.Equal(t, server.URL, quarantined.URL)
+	assert.Equal(t, rss.StateOpen, quarantined.State)
+	assert.Equal(t, 3, quarantined.Failures)
+	assert.Contains(t, quarantined.LastError, "500")
+
+	// отключенная лента не опрашивается до пробного запроса
+	before := server.Requests()
+	time.Sleep(150 * time.Millisecond)
+	assert.Equal(t, before, server.Requests())
+
+	// пробный запрос неудачен - лента снова отключается
+	assert.Eventually(t, func() bool { return server.Requests() == before+1 }, time.Second, 5*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, before+1, server.Requests())
+	assert.Equal(t, rss.StateOpen, r.Quarantined()[0].State)
+
+	server.SetStatus(func(request int) (int, http.Header) {
+		return http.StatusOK, nil
+	})
+	assert.True(t, r.Unquarantine(server.URL))
+	assert.False(t, r.Unquarantine(server.URL))
+	assert.Empty(t, r.Quarantined())
+
+	before = server.Requests()
+	assert.Eventually(t, func() bool { return server.Requests() > before+3 }, time.Second, 5*time.Millisecond)
+
+	assert.NoError(t, r.Stop())
+}
+
+func TestRssReader_CircuitBreakerHalfOpenRecovery(t *testing.T) {
+	server := newFlakyServer(t, func(request int) (int, http.Header) {
+		return http.StatusOK, nil
+	})
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetFeedConfig(server.URL, feed.Config{
+		Retry:           feed.RetryPolicy{Attempts: 1},
+		MaxPollInterval: 10 * time.Millisecond,
+		Breaker:         feed.BreakerPolicy{FailureThreshold: 2, OpenTimeout: 100 * time.Millisecond},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	assert.NoError(t, r.StartParsing(server.URL, "test", 10*time.Millisecond, ctx))
+
+	server.SetStatus(func(request int) (int, http.Header) {
+		return http.StatusBadGateway, nil
+	})
+	assert.Eventually(t, func() bool { return len(r.Quarantined()) == 1 }, time.Second, 5*time.Millisecond)
+
+	server.SetStatus(func(request int) (int, http.Header) {
+		return http.StatusOK, nil
+	})
+	assert.Eventually(t, func() bool { return len(r.Quarantined()) == 0 }, time.Second, 5*time.Millisecond)
+
+	assert.NoError(t, r.Stop())
+}
@@ -0,0 +1,38 @@
+package implementation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	rss "gafarov/rss-reader/internal/core/reader/implementation"
+	model "gafarov/rss-reader/internal/model/kafka"
+)
+
+func TestRssReader_GetChannelMetadata(t *testing.T) {
+	server := newFixtureServer(t, "channel.xml", "application/rss+xml")
+	r := rss.New(nil, nil)
+	defer r.Stop()
+
+	channel, err := r.GetChannel(server.URL, context.Background())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "https://example.com/", channel.Link)
+	assert.Equal(t, "https://example.com/logo.png", channel.Image.URL)
+	assert.Equal(t, "144", channel.Image.Width)
+	assert.Equal(t, "15", channel.TTL)
+	assert.Equal(t, "Mon, 05 Oct 2026 14:05:00 +0300", channel.LastBuildDate)
+	assert.Equal(t, "Mon, 05 Oct 2026 14:00:00 +0300", channel.PubDate)
+	assert.Equal(t, "CMS 3.1", channel.Generator)
+	assert.Equal(t, "© Реальное время", channel.Copyright)
+	assert.Equal(t, "editor@example.com (Редактор)", channel.ManagingEditor)
+	assert.Equal(t, []string{"Новости", "Татарстан"}, channel.Categories)
+	assert.Equal(t, "https://example.com/rss.xml", channel.SelfURL)
+	assert.Equal(t, []string{"https://pubsubhubbub.appspot.com/"}, channel.HubURLs)
+
+	kafkaChannel := (&model.Channel{}).ConvertFromRSS(channel, "code")
+	assert.Equal(t, "https://example.com/logo.png", kafkaChannel.Image.URL)
+	assert.Equal(t, "https://example.com/rss.xml", kafkaChannel.SelfURL)
+	assert.Equal(t, []string{"Новости", "Татарстан"}, kafkaChannel.Categories)
+}
@@ -0,0 +1,41 @@
+package implementation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	rss "gafarov/rss-reader/internal/core/reader/implementation"
+)
+
+func TestRssReader_ParseLegacyCharset(t *testing.T) {
+	cases := []struct {
+		name        string
+		file        string
+		contentType string
+	}{
+		{"windows-1251 prolog", "windows-1251.xml", "application/rss+xml"},
+		{"koi8-r prolog", "koi8-r.xml", "text/xml"},
+		{"windows-1251 header", "windows-1251-noprolog.xml", "text/xml; charset=windows-1251"},
+		{"utf-8 header with windows-1251 prolog", "windows-1251.xml", "text/xml; charset=utf-8"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server := newFixtureServer(t, c.file, c.contentType)
+			r := rss.New(nil, nil)
+			defer r.Stop()
+
+			items, err := r.ParseOnce(server.URL, context.Background())
+			assert.NoError(t, err)
+			assert.Len(t, items, 1)
+			assert.Equal(t, "Глава района открыл новую школу", items[0].Title)
+			assert.Equal(t, "В селе Ёлкино открылась школа на 200 мест", items[0].Description)
+
+			channel, err := r.GetChannel(server.URL, context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, "Региональные новости", channel.Title)
+		})
+	}
+}
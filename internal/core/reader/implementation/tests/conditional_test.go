@@ -0,0 +1,75 @@
+package implementation_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	rss "gafarov/rss-reader/internal/core/reader/implementation"
+)
+
+func TestRssReader_ConditionalGetETag(t *testing.T) {
+	body := strings.Replace(feedWithGuid, "%s", itemWithGuid("1", "Новость"), 1)
+	server := newFeedServer(t, body)
+	cache := newMemoryCache()
+
+	r := rss.New(cache, nil)
+	ctx := context.Background()
+
+	items, err := r.ParseOnce(server.URL, ctx)
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+
+	_, err = r.ParseOnce(server.URL, ctx)
+	assert.ErrorIs(t, err, rss.ErrNoItemsFound)
+
+	channel, err := r.GetChannel(server.URL, ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "Feed", channel.Title)
+	assert.Empty(t, channel.Items)
+	assert.Equal(t, 2, server.NotModified())
+
+	stats := r.Stats()
+	assert.Equal(t, int64(3), stats.Requests)
+	assert.Equal(t, int64(2), stats.NotModified)
+	assert.Equal(t, int64(len(body)), stats.BytesReceived)
+	assert.Equal(t, int64(2*len(body)), stats.BytesSaved)
+
+	// валидаторы хранятся в кэше и переживают перезапуск
+	restarted := rss.New(cache, nil)
+	_, err = restarted.ParseOnce(server.URL, ctx)
+	assert.ErrorIs(t, err, rss.ErrNoItemsFound)
+
+	// без сохраненной копии канал запрашивается целиком
+	channel, err = restarted.GetChannel(server.URL, ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "Feed", channel.Title)
+	assert.Equal(t, 3, server.NotModified())
+
+	server.SetBody(strings.Replace(feedWithGuid, "%s", itemWithGuid("2", "Новость 2")+itemWithGuid("1", "Новость"), 1))
+	items, err = r.ParseOnce(server.URL, ctx)
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+}
+
+func TestRssReader_ConditionalGetLastModified(t *testing.T) {
+	server := newFixtureServer(t, "channel.xml", "application/rss+xml")
+	info, err := os.Stat("testdata/channel.xml")
+	assert.NoError(t, err)
+
+	r := rss.New(newMemoryCache(), nil)
+	ctx := context.Background()
+
+	_, err = r.ParseOnce(server.URL, ctx)
+	assert.NoError(t, err)
+
+	_, err = r.ParseOnce(server.URL, ctx)
+	assert.ErrorIs(t, err, rss.ErrNoItemsFound)
+
+	stats := r.Stats()
+	assert.Equal(t, int64(1), stats.NotModified)
+	assert.Equal(t, info.Size(), stats.BytesSaved)
+}
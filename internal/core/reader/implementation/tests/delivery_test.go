@@ -0,0 +1,69 @@
+package implementation_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rss "gafarov/rss-reader/internal/core/reader/implementation"
+	"gafarov/rss-reader/internal/model/feed"
+)
+
+func TestRssReader_NewItemAfterRestart(t *testing.T) {
+	server := newFeedServer(t, strings.Replace(feedWithGuid, "%s", itemWithGuid("1", "Новость 1"), 1))
+	cache := newMemoryCache()
+
+	first := rss.New(cache, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, first.StartParsing(server.URL, "restart", time.Hour, ctx))
+	cancel()
+	require.NoError(t, first.Stop())
+
+	// после перезапуска первый опрос получает 304 по сохраненным валидаторам
+	restarted := rss.New(cache, nil)
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, restarted.StartParsing(server.URL, "restart", 20*time.Millisecond, ctx))
+	assert.Equal(t, 1, server.NotModified())
+
+	server.SetBody(strings.Replace(feedWithGuid, "%s", itemWithGuid("2", "Новость 2")+itemWithGuid("1", "Новость 1"), 1))
+
+	select {
+	case item := <-restarted.Output():
+		assert.Equal(t, "Новость 2", item.Title)
+	case <-time.After(2 * time.Second):
+		t.Fatal("new item not received after restart")
+	}
+}
+
+func TestRssReader_DroppedItemsRetried(t *testing.T) {
+	// в выходном канале 500 мест, последний элемент не помещается
+	var items strings.Builder
+	for i := 501; i >= 1; i-- {
+		items.WriteString(itemWithGuid(fmt.Sprint(i), fmt.Sprintf("Новость %d", i)))
+	}
+	server := newFeedServer(t, strings.Replace(feedWithGuid, "%s", items.String(), 1))
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetFeedConfig(server.URL, feed.Config{EmitExisting: true})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, r.StartParsing(server.URL, "full", 20*time.Millisecond, ctx))
+
+	seen := make(map[string]bool)
+	timeout := time.After(3 * time.Second)
+	for len(seen) < 501 {
+		select {
+		case item := <-r.Output():
+			seen[item.Title] = true
+		case <-timeout:
+			t.Fatalf("received %d of 501 items", len(seen))
+		}
+	}
+	assert.True(t, seen["Новость 1"])
+}
@@ -0,0 +1,111 @@
+package implementation_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	rss "gafarov/rss-reader/internal/core/reader/implementation"
+	"gafarov/rss-reader/internal/model/feed"
+)
+
+func newStatusServer(t *testing.T, status int, contentType, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRssReader_HTTPStatusError(t *testing.T) {
+	server := newStatusServer(t, http.StatusNotFound, "text/html", "<html><body>\n  <h1>Not Found</h1></body></html>")
+
+	r := rss.New(newMemoryCache(), nil)
+	_, err := r.ParseOnce(server.URL, context.Background())
+
+	var statusErr *rss.HTTPStatusError
+	if assert.True(t, errors.As(err, &statusErr)) {
+		assert.Equal(t, server.URL, statusErr.URL)
+		assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+		assert.Equal(t, "text/html", statusErr.ContentType)
+		assert.Equal(t, "<html><body> <h1>Not Found</h1></body></html>", statusErr.Snippet)
+	}
+
+	server = newStatusServer(t, http.StatusServiceUnavailable, "text/plain", "maintenance")
+	r.SetFeedConfig(server.URL, feed.Config{Retry: feed.RetryPolicy{Attempts: 1}})
+	_, err = r.GetChannel(server.URL, context.Background())
+	if assert.True(t, errors.As(err, &statusErr)) {
+		assert.Equal(t, http.StatusServiceUnavailable, statusErr.StatusCode)
+	}
+}
+
+func TestRssReader_ParseError(t *testing.T) {
+	server := newStatusServer(t, http.StatusOK, "text/html; charset=utf-8", "<!DOCTYPE html><html><body>Вход</body></html>")
+
+	r := rss.New(newMemoryCache(), nil)
+	_, err := r.ParseOnce(server.URL, context.Background())
+
+	var parseErr *rss.ParseError
+	if assert.True(t, errors.As(err, &parseErr)) {
+		assert.Equal(t, http.StatusOK, parseErr.StatusCode)
+		assert.Equal(t, "text/html; charset=utf-8", parseErr.ContentType)
+		assert.True(t, strings.HasPrefix(parseErr.Snippet, "<!DOCTYPE html>"))
+		assert.Error(t, parseErr.Err)
+	}
+}
+
+func TestRssReader_TimeoutError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		select {
+		case <-req.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	r := rss.New(newMemoryCache(), nil)
+	_, err := r.ParseOnce(server.URL, ctx)
+
+	var timeoutErr *rss.TimeoutError
+	if assert.True(t, errors.As(err, &timeoutErr)) {
+		assert.Equal(t, server.URL, timeoutErr.URL)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	}
+}
+
+type spaceReader struct{}
+
+func (spaceReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = ' '
+	}
+	return len(p), nil
+}
+
+func TestRssReader_TooLargeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = io.CopyN(w, spaceReader{}, rss.DefaultMaxBodySize+1024)
+	}))
+	t.Cleanup(server.Close)
+
+	r := rss.New(newMemoryCache(), nil)
+	_, err := r.ParseOnce(server.URL, context.Background())
+
+	var tooLargeErr *rss.TooLargeError
+	if assert.True(t, errors.As(err, &tooLargeErr)) {
+		assert.Equal(t, int64(rss.DefaultMaxBodySize), tooLargeErr.Limit)
+	}
+}
@@ -0,0 +1,90 @@
+package implementation_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	rss "gafarov/rss-reader/internal/core/reader/implementation"
+	"gafarov/rss-reader/internal/model/feed"
+	model "gafarov/rss-reader/internal/model/rss"
+)
+
+func TestItemID_Strategies(t *testing.T) {
+	item := &model.Item{
+		Title:   "Новость",
+		Link:    "https://www.Example.com/news/1/?utm_source=rss&b=2&a=1#comments",
+		PubDate: "Mon, 05 Oct 2026 14:00:00 +0300",
+		Guid:    model.Guid{Value: "news-1", IsPermaLink: "false"},
+	}
+
+	assert.Equal(t, "news-1", rss.ItemID(item, ""))
+	assert.Equal(t, "news-1", rss.ItemID(item, feed.IdentityGuid))
+	assert.Equal(t, item.Link, rss.ItemID(item, feed.IdentityLink))
+	assert.Equal(t, "example.com/news/1?a=1&b=2", rss.ItemID(item, feed.IdentityNormalizedLink))
+
+	hash := rss.ItemID(item, feed.IdentityHash)
+	assert.True(t, strings.HasPrefix(hash, "sha1:"))
+
+	edited := *item
+	edited.Link = "http://example.com/news/1?a=1&b=2&utm_medium=feed"
+	assert.Equal(t, hash, rss.ItemID(&edited, feed.IdentityHash))
+
+	edited.Title = "Другая новость"
+	assert.NotEqual(t, hash, rss.ItemID(&edited, feed.IdentityHash))
+}
+
+func TestItemID_Fallbacks(t *testing.T) {
+	permaLink := &model.Item{Guid: model.Guid{Value: "https://example.com/news/1"}}
+	assert.Equal(t, "https://example.com/news/1", rss.ItemID(permaLink, feed.IdentityLink))
+	assert.Equal(t, "example.com/news/1", rss.ItemID(permaLink, feed.IdentityNormalizedLink))
+
+	notPermaLink := &model.Item{Guid: model.Guid{Value: "https://example.com/news/1", IsPermaLink: "false"}}
+	assert.Equal(t, "https://example.com/news/1", rss.ItemID(notPermaLink, feed.IdentityLink))
+
+	noGuid := &model.Item{Title: "Новость", Link: "https://example.com/news/2"}
+	assert.Equal(t, "https://example.com/news/2", rss.ItemID(noGuid, feed.IdentityGuid))
+
+	empty := &model.Item{Title: "Новость"}
+	assert.True(t, strings.HasPrefix(rss.ItemID(empty, feed.IdentityGuid), "sha1:"))
+}
+
+const feedWithoutGuid = `<rss version="2.0"><channel><title>Feed</title>%s</channel></rss>`
+
+func itemWithoutGuid(n string) string {
+	return `<item><title>Новость ` + n + `</title><link>https://example.com/news/` + n + `</link></item>`
+}
+
+func TestRssReader_DedupWithoutGuid(t *testing.T) {
+	server := newFeedServer(t, strings.Replace(feedWithoutGuid, "%s", itemWithoutGuid("1")+itemWithoutGuid("2"), 1))
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetFeedConfig(server.URL, feed.Config{Identity: feed.IdentityNormalizedLink})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := r.StartParsing(server.URL, "test", 20*time.Millisecond, ctx)
+	assert.NoError(t, err)
+
+	server.SetBody(strings.Replace(feedWithoutGuid, "%s", itemWithoutGuid("3")+itemWithoutGuid("1")+itemWithoutGuid("2"), 1))
+
+	select {
+	case item := <-r.Output():
+		assert.Equal(t, "Новость 3", item.Title)
+		assert.Equal(t, "example.com/news/3", item.ID)
+	case <-time.After(2 * time.Second):
+		t.Fatal("new item was not emitted")
+	}
+
+	select {
+	case item := <-r.Output():
+		t.Fatalf("unexpected item %q", item.Title)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	assert.NoError(t, r.Stop())
+}
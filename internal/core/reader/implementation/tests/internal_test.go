@@ -35,3 +35,74 @@ func TestParseRSSDate(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDate_RealWorldLayouts(t *testing.T) {
+	expected := time.Date(2026, 10, 5, 11, 0, 0, 0, time.UTC)
+	cases := []string{
+		"Пн, 05 окт 2026 14:00:00 +0300",
+		"Понедельник, 5 октября 2026 г., 14:00 MSK",
+		"5 Окт. 2026 14:00 мск",
+		"Mon, 5 Oct 2026 14:00:00 MSK",
+		"Mon, 05 Oct 2026 13:00:00 EET",
+		"Mon, 05 Oct 2026 14:00:00 EEST",
+		"Mon, 05 Oct 2026 14:00:00 +0300 (MSK)",
+		"Mon, 05 Oct 2026 14:00:00 GMT+3",
+		"Mon Oct  5 14:00:00 MSK 2026",
+		"Monday, 05-Oct-26 11:00:00 GMT",
+		"October 5, 2026 11:00 AM UTC",
+		"2026-10-05T14:00:00+03:00",
+		"2026-10-05 11:00:00Z",
+		"1791198000",
+	}
+
+	for _, c := range cases {
+		t.Run(c, func(t *testing.T) {
+			date, err := implementation.ParseRSSDate(c)
+			assert.NoError(t, err)
+			if assert.NotNil(t, date) {
+				assert.Equal(t, expected, *date)
+				assert.Equal(t, time.UTC, date.Location())
+			}
+		})
+	}
+}
+
+func TestParseDate_DefaultLocation(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	assert.NoError(t, err)
+
+	expected := time.Date(2026, 10, 5, 11, 0, 0, 0, time.UTC)
+	cases := []string{
+		"05.10.2026 14:00",
+		"5.10.2026 14:00:00",
+		"14:00 05.10.2026",
+		"2026-10-05 14:00:00",
+		"5 октября 2026 14:00",
+	}
+
+	for _, c := range cases {
+		t.Run(c, func(t *testing.T) {
+			date, err := implementation.ParseDate(c, moscow)
+			assert.NoError(t, err)
+			if assert.NotNil(t, date) {
+				assert.Equal(t, expected, *date)
+			}
+		})
+	}
+
+	date, err := implementation.ParseDate("Mon, 05 Oct 2026 11:00:00 +0000", moscow)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, *date)
+
+	date, err = implementation.ParseRSSDate("05.10.2026 11:00")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, *date)
+}
+
+func TestParseDate_Invalid(t *testing.T) {
+	for _, c := range []string{"", "вчера", "32.13.2026 25:00"} {
+		date, err := implementation.ParseRSSDate(c)
+		assert.Error(t, err)
+		assert.Nil(t, date)
+	}
+}
@@ -0,0 +1,52 @@
+package implementation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	rss "gafarov/rss-reader/internal/core/reader/implementation"
+)
+
+func TestRssReader_ParseJSONFeed(t *testing.T) {
+	server := newFixtureServer(t, "jsonfeed.json", "application/feed+json")
+	r := rss.New(nil, nil)
+	defer r.Stop()
+
+	items, err := r.ParseOnce(server.URL, context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+
+	item := items[0]
+	assert.Equal(t, "Первая новость", item.Title)
+	assert.Equal(t, "https://example.com/news/1", item.Guid.Value)
+	assert.Equal(t, "https://example.com/news/1", item.Link)
+	assert.Equal(t, "<p>Полный текст</p>", item.Fulltext)
+	assert.Equal(t, "Краткое описание", item.Description)
+	assert.Equal(t, "Иван Петров", item.Author)
+	assert.Equal(t, []string{"politics", "society"}, item.Category)
+	assert.Equal(t, "https://example.com/news/1.mp3", item.Enclosure.URL)
+	assert.Equal(t, "audio/mpeg", item.Enclosure.Type)
+	assert.NotNil(t, item.PubTimeParsed)
+
+	item = items[1]
+	assert.Equal(t, "https://partner.example.com/news/2", item.Link)
+	assert.Equal(t, "Текст без разметки", item.Fulltext)
+	assert.Equal(t, "2026-10-05T12:00:00Z", item.PubDate)
+	assert.Equal(t, "Редакция", item.Author)
+	assert.Equal(t, "https://example.com/news/2.jpg", item.Enclosure.URL)
+}
+
+func TestRssReader_GetJSONFeedChannel(t *testing.T) {
+	server := newFixtureServer(t, "jsonfeed.json", "application/feed+json")
+	r := rss.New(nil, nil)
+	defer r.Stop()
+
+	channel, err := r.GetChannel(server.URL, context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "Пример JSON Feed", channel.Title)
+	assert.Equal(t, "https://example.com/", channel.Link)
+	assert.Equal(t, "Лента в формате JSON Feed", channel.Description)
+	assert.Equal(t, "ru", channel.Language)
+}
@@ -0,0 +1,165 @@
+package implementation_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	rss "gafarov/rss-reader/internal/core/reader/implementation"
+)
+
+// concurrencyProbe считает одновременные запросы к серверам
+type concurrencyProbe struct {
+	inFlight atomic.Int32
+	max      atomic.Int32
+}
+
+func (p *concurrencyProbe) server(t *testing.T, delay time.Duration) *httptest.Server {
+	body := strings.Replace(feedWithGuid, "%s", itemWithGuid("1", "Новость"), 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		current := p.inFlight.Add(1)
+		defer p.inFlight.Add(-1)
+		for {
+			max := p.max.Load()
+			if current <= max || p.max.CompareAndSwap(max, current) {
+				break
+			}
+		}
+		time.Sleep(delay)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func parseConcurrently(r *rss.RssReader, urls []string) {
+	wg := sync.WaitGroup{}
+	for _, url := range urls {
+		wg.Go(func() {
+			_, _ = r.ParseOnce(url, context.Background())
+		})
+	}
+	wg.Wait()
+}
+
+func TestRssReader_PerHostConcurrency(t *testing.T) {
+	probe := &concurrencyProbe{}
+	server := probe.server(t, 50*time.Millisecond)
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetLimits(rss.Limits{PerHostConcurrency: 2})
+
+	var urls []string
+	for i := range 6 {
+		urls = append(urls, fmt.Sprintf("%s/feed?%d", server.URL, i))
+	}
+	parseConcurrently(r, urls)
+
+	assert.Equal(t, int32(2), probe.max.Load())
+}
+
+func TestRssReader_PerHostRate(t *testing.T) {
+	probe := &concurrencyProbe{}
+	server := probe.server(t, 0)
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetLimits(rss.Limits{PerHostRate: 20})
+
+	var urls []string
+	for i := range 5 {
+		urls = append(urls, fmt.Sprintf("%s/feed?%d", server.URL, i))
+	}
+
+	start := time.Now()
+	parseConcurrently(r, urls)
+	// первый запрос сразу, остальные с интервалом 50 мс
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
+
+func TestRssReader_GlobalConcurrency(t *testing.T) {
+	probe := &concurrencyProbe{}
+	first := probe.server(t, 30*time.Millisecond)
+	second := probe.server(t, 30*time.Millisecond)
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetLimits(rss.Limits{GlobalConcurrency: 1})
+
+	parseConcurrently(r, []string{first.URL + "/a", first.URL + "/b", second.URL + "/a", second.URL + "/b"})
+
+	assert.Equal(t, int32(1), probe.max.Load())
+}
+
+func TestRssReader_LimiterHonorsContext(t *testing.T) {
+	probe := &concurrencyProbe{}
+	server := probe.server(t, 0)
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetLimits(rss.Limits{PerHostRate: 0.1})
+
+	_, err := r.ParseOnce(server.URL, context.Background())
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = r.ParseOnce(server.URL+"/other", ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRssReader_RateWaitDoesNotBlockOtherHosts(t *testing.T) {
+	probe := &concurrencyProbe{}
+	first := probe.server(t, 0)
+	second := probe.server(t, 0)
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetLimits(rss.Limits{PerHostRate: 0.5, GlobalConcurrency: 1})
+
+	_, err := r.ParseOnce(first.URL, context.Background())
+	assert.NoError(t, err)
+
+	// второй запрос к первому хосту ждет окна частоты и не должен держать общий слот
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := r.ParseOnce(first.URL+"/other", ctx)
+		done <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	start := time.Now()
+	_, err = r.ParseOnce(second.URL, context.Background())
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	assert.ErrorIs(t, <-done, context.DeadlineExceeded)
+}
+
+func TestRssReader_CancelledRequestFreesRateWindow(t *testing.T) {
+	probe := &concurrencyProbe{}
+	server := probe.server(t, 0)
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetLimits(rss.Limits{PerHostRate: 5})
+
+	start := time.Now()
+	_, err := r.ParseOnce(server.URL, context.Background())
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = r.ParseOnce(server.URL+"/cancelled", ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// отмененный запрос не занимает окно: следующий идет через 200 мс, а не через 400
+	_, err = r.ParseOnce(server.URL+"/next", context.Background())
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(start), 350*time.Millisecond)
+}
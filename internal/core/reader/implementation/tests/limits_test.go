@@ -0,0 +1,114 @@
+package implementation_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	rss "gafarov/rss-reader/internal/core/reader/implementation"
+	"gafarov/rss-reader/internal/model/feed"
+)
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	buffer := &bytes.Buffer{}
+	writer := gzip.NewWriter(buffer)
+	_, err := writer.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+	return buffer.Bytes()
+}
+
+func newGzipServer(t *testing.T, data []byte) *httptest.Server {
+	compressed := gzipBytes(t, data)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		if strings.Contains(req.Header.Get("Accept-Encoding"), "gzip") {
+			w.Header().Set("Content-Encoding", "gzip")
+			_, _ = w.Write(compressed)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func documentConfig(limits feed.DocumentLimits) feed.Config {
+	return feed.Config{Retry: feed.RetryPolicy{Attempts: 1}, Document: limits}
+}
+
+func TestRssReader_GzipResponse(t *testing.T) {
+	body := strings.Replace(feedWithGuid, "%s", itemWithGuid("1", "Новость"), 1)
+	server := newGzipServer(t, []byte(body))
+
+	r := rss.New(newMemoryCache(), nil)
+	items, err := r.ParseOnce(server.URL, context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Less(t, r.Stats().BytesReceived, int64(len(body)))
+}
+
+func TestRssReader_DecompressionBomb(t *testing.T) {
+	// 32 МБ пробелов сжимаются примерно до 32 КБ
+	bomb := append([]byte(`<rss version="2.0"><channel>`), bytes.Repeat([]byte(" "), 32<<20)...)
+	server := newGzipServer(t, bomb)
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetFeedConfig(server.URL, documentConfig(feed.DocumentLimits{MaxBodySize: 1 << 20}))
+
+	_, err := r.ParseOnce(server.URL, context.Background())
+	var tooLargeErr *rss.TooLargeError
+	if assert.ErrorAs(t, err, &tooLargeErr) {
+		assert.Equal(t, rss.LimitBody, tooLargeErr.Kind)
+		assert.Equal(t, int64(1<<20), tooLargeErr.Limit)
+	}
+	assert.Less(t, r.Stats().BytesReceived, int64(1<<20))
+}
+
+func TestRssReader_MaxCompressedSize(t *testing.T) {
+	body := strings.Replace(feedWithGuid, "%s", itemWithGuid("1", "Новость"), 1)
+	server := newGzipServer(t, []byte(body))
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetFeedConfig(server.URL, documentConfig(feed.DocumentLimits{MaxCompressedSize: 64}))
+
+	_, err := r.ParseOnce(server.URL, context.Background())
+	var tooLargeErr *rss.TooLargeError
+	if assert.ErrorAs(t, err, &tooLargeErr) {
+		assert.Equal(t, rss.LimitCompressedBody, tooLargeErr.Kind)
+		assert.Equal(t, int64(64), tooLargeErr.Limit)
+	}
+}
+
+func TestRssReader_MaxItemsAndDepth(t *testing.T) {
+	body := strings.Replace(feedWithGuid, "%s",
+		itemWithGuid("1", "Новость 1")+itemWithGuid("2", "Новость 2")+itemWithGuid("3", "Новость 3"), 1)
+	server := newFeedServer(t, body)
+
+	r := rss.New(newMemoryCache(), nil)
+	var tooLargeErr *rss.TooLargeError
+
+	r.SetFeedConfig(server.URL, documentConfig(feed.DocumentLimits{MaxItems: 2}))
+	_, err := r.ParseOnce(server.URL, context.Background())
+	if assert.ErrorAs(t, err, &tooLargeErr) {
+		assert.Equal(t, rss.LimitItems, tooLargeErr.Kind)
+		assert.Equal(t, int64(2), tooLargeErr.Limit)
+	}
+
+	r.SetFeedConfig(server.URL, documentConfig(feed.DocumentLimits{MaxDepth: 3}))
+	_, err = r.ParseOnce(server.URL, context.Background())
+	if assert.ErrorAs(t, err, &tooLargeErr) {
+		assert.Equal(t, rss.LimitDepth, tooLargeErr.Kind)
+	}
+
+	r.SetFeedConfig(server.URL, documentConfig(feed.DocumentLimits{MaxItems: 3, MaxDepth: 4}))
+	items, err := r.ParseOnce(server.URL, context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, items, 3)
+}
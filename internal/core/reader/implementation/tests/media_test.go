@@ -0,0 +1,101 @@
+package implementation_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	rss "gafarov/rss-reader/internal/core/reader/implementation"
+	model "gafarov/rss-reader/internal/model/kafka"
+)
+
+func TestRssReader_ParseITunes(t *testing.T) {
+	server := newFixtureServer(t, "media.xml", "application/rss+xml")
+	r := rss.New(nil, nil)
+	defer r.Stop()
+
+	items, err := r.ParseOnce(server.URL, context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+
+	item := items[0]
+	assert.Equal(t, "Выпуск 12", item.Title)
+	assert.Equal(t, "Двенадцатый выпуск", item.ITunes.ITunesTitle)
+	assert.Equal(t, "Иван Петров", item.Author)
+	assert.Equal(t, "О главном за неделю", item.Description)
+	assert.Equal(t, "00:41:15", item.ITunes.ITunesDuration)
+	assert.Equal(t, "12", item.ITunes.ITunesEpisode)
+	assert.Equal(t, "2", item.ITunes.ITunesSeason)
+	assert.Equal(t, "full", item.ITunes.ITunesEpisodeType)
+	assert.Equal(t, "https://example.com/podcast/12.jpg", item.ITunes.ITunesImage.Href)
+
+	assert.Len(t, item.Media, 1)
+	assert.Equal(t, "https://example.com/podcast/12.mp3", item.Media[0].URL)
+	assert.Equal(t, "audio", item.Media[0].Medium)
+	assert.Equal(t, int64(24986239), item.Media[0].Length)
+}
+
+func TestRssReader_ParseMediaRSS(t *testing.T) {
+	server := newFixtureServer(t, "media.xml", "application/rss+xml")
+	r := rss.New(nil, nil)
+	defer r.Stop()
+
+	items, err := r.ParseOnce(server.URL, context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+
+	item := items[1]
+	assert.Equal(t, "Фоторепортаж", item.Title)
+	assert.Equal(t, "Мост открыли", item.Description)
+	assert.Equal(t, "Анна Смирнова", item.Author)
+	assert.Equal(t, "2026-10-05T12:00:00+03:00", item.PubDate)
+	assert.NotNil(t, item.PubTimeParsed)
+
+	assert.Len(t, item.Media, 3)
+
+	photo := item.Media[0]
+	assert.Equal(t, "https://example.com/photo/1-1.jpg", photo.URL)
+	assert.Equal(t, "image", photo.Medium)
+	assert.Equal(t, 1200, photo.Width)
+	assert.Equal(t, 800, photo.Height)
+	assert.Equal(t, int64(204800), photo.Length)
+	assert.Equal(t, "Открытие моста", photo.Title)
+	assert.Equal(t, "Снимки с открытия моста", photo.Description)
+	assert.Equal(t, "https://example.com/photo/1-small.jpg", photo.Thumbnails[0].URL)
+
+	photo = item.Media[1]
+	assert.Equal(t, "Вид с набережной", photo.Description)
+	assert.Equal(t, "https://example.com/photo/1-2-small.jpg", photo.Thumbnails[0].URL)
+	assert.Equal(t, 160, photo.Thumbnails[0].Width)
+
+	video := item.Media[2]
+	assert.Equal(t, "video", video.Medium)
+	assert.Equal(t, 95, video.Duration)
+	assert.Equal(t, 1920, video.Width)
+}
+
+func TestRssReader_MediaInKafkaMessage(t *testing.T) {
+	server := newFixtureServer(t, "media.xml", "application/rss+xml")
+	r := rss.New(nil, nil)
+	defer r.Stop()
+
+	items, err := r.ParseOnce(server.URL, context.Background())
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(model.Message{NewsItem: *items[0]})
+	assert.NoError(t, err)
+
+	var payload struct {
+		NewsItem struct {
+			Media  []map[string]any `json:"media"`
+			ITunes map[string]any   `json:"itunes"`
+		} `json:"newsItem"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &payload))
+	assert.Len(t, payload.NewsItem.Media, 1)
+	assert.Equal(t, "https://example.com/podcast/12.mp3", payload.NewsItem.Media[0]["url"])
+	assert.Equal(t, "12", payload.NewsItem.ITunes["episode"])
+	assert.NotContains(t, string(data), "MediaContents")
+}
@@ -0,0 +1,208 @@
+package implementation_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	rss "gafarov/rss-reader/internal/core/reader/implementation"
+	"gafarov/rss-reader/internal/model/feed"
+)
+
+// tokenServer выдает токены token-1, token-2... по client credentials
+type tokenServer struct {
+	*httptest.Server
+	mu     sync.Mutex
+	issued int
+	forms  []string
+}
+
+func newTokenServer(t *testing.T, clientID, secret string) *tokenServer {
+	s := &tokenServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		username, password, ok := req.BasicAuth()
+		if err := req.ParseForm(); err != nil || !ok || username != clientID || password != secret ||
+			req.PostForm.Get("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+
+		s.mu.Lock()
+		s.issued++
+		s.forms = append(s.forms, req.PostForm.Encode())
+		token := fmt.Sprintf("token-%d", s.issued)
+		s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": token,
+			"token_type":   "bearer",
+			"expires_in":   3600,
+		})
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *tokenServer) Issued() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.issued
+}
+
+// protectedServer принимает только токен, возвращаемый valid
+func newProtectedServer(t *testing.T, valid func() string) *httptest.Server {
+	body := strings.Replace(feedWithGuid, "%s", itemWithGuid("1", "Новость"), 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer "+valid() {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func oauthConfig(tokenURL string) feed.Config {
+	return feed.Config{HTTP: feed.HTTPConfig{OAuth2: &feed.OAuth2Config{
+		TokenURL:     tokenURL,
+		ClientID:     "reader",
+		ClientSecret: "secret",
+		Scopes:       []string{"feeds.read", "feeds.list"},
+	}}}
+}
+
+func TestRssReader_OAuth2ClientCredentials(t *testing.T) {
+	tokens := newTokenServer(t, "reader", "secret")
+	server := newProtectedServer(t, func() string { return "token-1" })
+	cache := newMemoryCache()
+
+	r := rss.New(cache, nil)
+	r.SetFeedConfig(server.URL, oauthConfig(tokens.URL))
+
+	items, err := r.ParseOnce(server.URL, context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, 1, tokens.Issued())
+	assert.Equal(t, []string{"grant_type=client_credentials&scope=feeds.read+feeds.list"}, tokens.forms)
+
+	// токен берется из кэша, в том числе другим экземпляром ридера
+	other := rss.New(cache, nil)
+	other.SetFeedConfig(server.URL, oauthConfig(tokens.URL))
+	_, err = other.GetChannel(server.URL, context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, tokens.Issued())
+}
+
+func TestRssReader_OAuth2RefreshOn401(t *testing.T) {
+	tokens := newTokenServer(t, "reader", "secret")
+
+	var (
+		mu    sync.Mutex
+		valid = "token-1"
+	)
+	server := newProtectedServer(t, func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		return valid
+	})
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetFeedConfig(server.URL, oauthConfig(tokens.URL))
+
+	_, err := r.ParseOnce(server.URL, context.Background())
+	assert.NoError(t, err)
+
+	// сервер отозвал токен раньше срока
+	mu.Lock()
+	valid = "token-2"
+	mu.Unlock()
+
+	_, err = r.GetChannel(server.URL, context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, tokens.Issued())
+
+	// повтор только один: новый токен тоже отклонен
+	mu.Lock()
+	valid = "never"
+	mu.Unlock()
+
+	_, err = r.GetChannel(server.URL, context.Background())
+	var statusErr *rss.HTTPStatusError
+	if assert.ErrorAs(t, err, &statusErr) {
+		assert.Equal(t, http.StatusUnauthorized, statusErr.StatusCode)
+	}
+	assert.Equal(t, 3, tokens.Issued())
+}
+
+func TestRssReader_OAuth2InvalidClient(t *testing.T) {
+	tokens := newTokenServer(t, "reader", "other-secret")
+	server := newProtectedServer(t, func() string { return "token-1" })
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetFeedConfig(server.URL, oauthConfig(tokens.URL))
+
+	_, err := r.ParseOnce(server.URL, context.Background())
+	var statusErr *rss.HTTPStatusError
+	if assert.ErrorAs(t, err, &statusErr) {
+		assert.Equal(t, tokens.URL, statusErr.URL)
+		assert.Equal(t, http.StatusUnauthorized, statusErr.StatusCode)
+		assert.NotContains(t, err.Error(), "other-secret")
+	}
+}
+
+func TestRssReader_OAuth2TokenWithoutCache(t *testing.T) {
+	tokens := newTokenServer(t, "reader", "secret")
+	server := newProtectedServer(t, func() string { return "token-1" })
+
+	r := rss.New(nil, nil)
+	r.SetFeedConfig(server.URL, oauthConfig(tokens.URL))
+
+	for range 3 {
+		_, err := r.ParseOnce(server.URL, context.Background())
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, tokens.Issued())
+}
+
+func TestRssReader_OAuth2SlowTokenServerDoesNotBlockOthers(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(slow.Close)
+	t.Cleanup(func() { close(release) })
+
+	tokens := newTokenServer(t, "reader", "secret")
+	first := newProtectedServer(t, func() string { return "token-1" })
+	second := newProtectedServer(t, func() string { return "token-1" })
+
+	r := rss.New(newMemoryCache(), nil)
+	firstConfig := oauthConfig(slow.URL)
+	firstConfig.Retry = feed.RetryPolicy{Attempts: 1}
+	r.SetFeedConfig(first.URL, firstConfig)
+	r.SetFeedConfig(second.URL, oauthConfig(tokens.URL))
+
+	go func() { _, _ = r.ParseOnce(first.URL, context.Background()) }()
+	<-started
+
+	// сервер токенов первой ленты еще не ответил, а вторая лента получает свой токен
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	items, err := r.ParseOnce(second.URL, ctx)
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+}
@@ -0,0 +1,48 @@
+package implementation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	rss "gafarov/rss-reader/internal/core/reader/implementation"
+)
+
+func TestRssReader_ParseRDF(t *testing.T) {
+	server := newFixtureServer(t, "rdf.xml", "application/rdf+xml")
+	r := rss.New(nil, nil)
+	defer r.Stop()
+
+	items, err := r.ParseOnce(server.URL, context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+
+	item := items[0]
+	assert.Equal(t, "Первая новость", item.Title)
+	assert.Equal(t, "https://example.com/news/1", item.Guid.Value)
+	assert.Equal(t, "https://example.com/news/1", item.Link)
+	assert.Equal(t, "Краткое описание", item.Description)
+	assert.Equal(t, "<p>Полный текст</p>", item.Fulltext)
+	assert.Equal(t, "Иван Петров", item.Author)
+	assert.Equal(t, []string{"politics"}, item.Category)
+	assert.Equal(t, "https://example.com/news/1.mp3", item.Enclosure.URL)
+	assert.Equal(t, "audio/mpeg", item.Enclosure.Type)
+	assert.NotNil(t, item.PubTimeParsed)
+
+	assert.Equal(t, "Вторая новость", items[1].Title)
+	assert.NotNil(t, items[1].PubTimeParsed)
+}
+
+func TestRssReader_GetRDFChannel(t *testing.T) {
+	server := newFixtureServer(t, "rdf.xml", "application/rdf+xml")
+	r := rss.New(nil, nil)
+	defer r.Stop()
+
+	channel, err := r.GetChannel(server.URL, context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "Пример RDF", channel.Title)
+	assert.Equal(t, "https://example.com/", channel.Link)
+	assert.Equal(t, "Лента в формате RSS 1.0", channel.Description)
+	assert.Equal(t, "ru", channel.Language)
+}
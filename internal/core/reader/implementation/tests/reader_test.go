@@ -6,12 +6,24 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	fetchers "gafarov/rss-reader/internal/core/fetcher/implementation"
 	rss "gafarov/rss-reader/internal/core/reader/implementation"
 )
 
 var RSS_URL = "https://realnoevremya.ru/rss/yandex-dzen.xml"
 
+// newReplayReader отдает записанный ответ RSS_URL вместо запроса к сайту
+func newReplayReader(t *testing.T) *rss.RssReader {
+	replay, err := fetchers.NewReplay("testdata/replay")
+	require.NoError(t, err)
+
+	r := rss.New(nil, nil)
+	r.RegisterFetcher("https", replay)
+	return r
+}
+
 func TestRssReader_IsStopped(t *testing.T) {
 	r := rss.New(nil, nil)
 	assert.False(t, r.IsStopped())
@@ -34,7 +46,7 @@ func TestRssReader_DoubleClose(t *testing.T) {
 }
 
 func TestRssReader_StartParsing(t *testing.T) {
-	r := rss.New(nil, nil)
+	r := newReplayReader(t)
 	ctx := context.Background()
 
 	err := r.StartParsing(RSS_URL, "test", time.Second, ctx)
@@ -47,7 +59,7 @@ func TestRssReader_StartParsing(t *testing.T) {
 }
 
 func TestRssReader_GetItems(t *testing.T) {
-	r := rss.New(nil, nil)
+	r := newReplayReader(t)
 	ctx := context.Background()
 
 	items, err := r.ParseOnce(RSS_URL, ctx)
@@ -60,7 +72,7 @@ func TestRssReader_GetItems(t *testing.T) {
 }
 
 func TestRssReader_GetItemContent(t *testing.T) {
-	r := rss.New(nil, nil)
+	r := newReplayReader(t)
 	ctx := context.Background()
 
 	items, err := r.ParseOnce(RSS_URL, ctx)
@@ -80,7 +92,7 @@ func TestRssReader_GetItemContent(t *testing.T) {
 }
 
 func TestRssReader_DoubleStart(t *testing.T) {
-	r := rss.New(nil, nil)
+	r := newReplayReader(t)
 	ctx := context.Background()
 
 	err := r.StartParsing(RSS_URL, "test", time.Second, ctx)
@@ -0,0 +1,163 @@
+package implementation_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	rss "gafarov/rss-reader/internal/core/reader/implementation"
+	"gafarov/rss-reader/internal/model/feed"
+)
+
+// movingServer отдает ленту по /new и перенаправляет на нее с /old
+type movingServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	status   int
+	requests map[string]int
+}
+
+func newMovingServer(t *testing.T, status int) *movingServer {
+	body := strings.Replace(feedWithGuid, "%s", itemWithGuid("1", "Новость"), 1)
+	s := &movingServer{status: status, requests: make(map[string]int)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s.mu.Lock()
+		s.requests[req.URL.Path]++
+		status := s.status
+		s.mu.Unlock()
+
+		switch req.URL.Path {
+		case "/old":
+			http.Redirect(w, req, "/new", status)
+		case "/new":
+			_, _ = w.Write([]byte(body))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *movingServer) Requests(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[path]
+}
+
+func TestRssReader_PermanentRedirect(t *testing.T) {
+	for _, status := range []int{http.StatusMovedPermanently, http.StatusPermanentRedirect} {
+		server := newMovingServer(t, status)
+		cache := newMemoryCache()
+		oldURL, newURL := server.URL+"/old", server.URL+"/new"
+
+		r := rss.New(cache, nil)
+		r.SetFeedConfig(oldURL, feed.Config{Identity: feed.IdentityLink})
+
+		items, err := r.ParseOnce(oldURL, context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com/news/1", items[0].ID)
+		assert.Equal(t, map[string]string{oldURL: newURL}, r.Moved())
+
+		// последующие запросы идут сразу на новый адрес, настройки ленты сохраняются
+		items, err = r.ParseOnce(oldURL, context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com/news/1", items[0].ID)
+		_, err = r.GetChannel(oldURL, context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, server.Requests("/old"))
+
+		// новый адрес хранится в кэше и переживает перезапуск
+		restarted := rss.New(cache, nil)
+		_, err = restarted.ParseOnce(oldURL, context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, server.Requests("/old"))
+		assert.Equal(t, map[string]string{oldURL: newURL}, restarted.Moved())
+	}
+}
+
+func TestRssReader_TemporaryRedirect(t *testing.T) {
+	server := newMovingServer(t, http.StatusFound)
+	oldURL := server.URL + "/old"
+
+	r := rss.New(newMemoryCache(), nil)
+	for range 2 {
+		_, err := r.ParseOnce(oldURL, context.Background())
+		assert.NoError(t, err)
+	}
+
+	assert.Empty(t, r.Moved())
+	assert.Equal(t, 2, server.Requests("/old"))
+}
+
+func TestRssReader_PollingFollowsMove(t *testing.T) {
+	server := newMovingServer(t, http.StatusMovedPermanently)
+	oldURL, newURL := server.URL+"/old", server.URL+"/new"
+
+	r := rss.New(newMemoryCache(), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	assert.NoError(t, r.StartParsing(oldURL, "test", 10*time.Millisecond, ctx))
+	time.Sleep(100 * time.Millisecond)
+
+	assert.Equal(t, 1, server.Requests("/old"))
+	assert.Greater(t, server.Requests("/new"), 3)
+
+	// лента уже опрашивается по новому адресу
+	assert.ErrorIs(t, r.StartParsing(newURL, "test", 10*time.Millisecond, ctx), rss.ErrAlreadyStarted)
+	assert.ErrorIs(t, r.StartParsing(oldURL, "test", 10*time.Millisecond, ctx), rss.ErrAlreadyStarted)
+
+	assert.NoError(t, r.Stop())
+}
+
+func TestRssReader_RedirectToAnotherHost(t *testing.T) {
+	body := strings.Replace(feedWithGuid, "%s", itemWithGuid("1", "Новость"), 1)
+
+	var (
+		mu      sync.Mutex
+		leaked  []string
+		targets int
+	)
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		targets++
+		if req.Header.Get("Authorization") != "" || req.URL.Query().Get("k") != "" {
+			leaked = append(leaked, req.URL.String())
+		}
+		mu.Unlock()
+		_, _ = w.Write([]byte(body))
+	}))
+	defer target.Close()
+	// другой хост, а не только порт
+	targetURL := strings.Replace(target.URL, "127.0.0.1", "localhost", 1) + "/feed"
+
+	origin := newFeedServer(t, body)
+	origin.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Redirect(w, req, targetURL, http.StatusMovedPermanently)
+	})
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetFeedConfig(origin.URL, feed.Config{HTTP: feed.HTTPConfig{
+		BearerToken: "SECRET",
+		Query:       map[string]string{"k": "APIKEY"},
+	}})
+
+	for range 2 {
+		_, err := r.ParseOnce(origin.URL, context.Background())
+		assert.NoError(t, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 2, targets)
+	assert.Empty(t, leaked)
+	assert.Empty(t, r.Moved())
+}
@@ -0,0 +1,107 @@
+package implementation_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fetchers "gafarov/rss-reader/internal/core/fetcher/implementation"
+	rss "gafarov/rss-reader/internal/core/reader/implementation"
+	"gafarov/rss-reader/internal/model/feed"
+	model "gafarov/rss-reader/internal/model/rss"
+)
+
+func titles(items []*model.Item) []string {
+	var result []string
+	for _, item := range items {
+		result = append(result, item.Title)
+	}
+	return result
+}
+
+func TestRssReader_RecordReplay(t *testing.T) {
+	server := newFeedServer(t, strings.Replace(feedWithGuid, "%s", itemWithGuid("1", "Новость 1"), 1))
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	recorder, err := fetchers.NewRecorder(dir, nil)
+	require.NoError(t, err)
+	r := rss.New(newMemoryCache(), nil)
+	r.WrapFetcher(recorder.Wrap)
+
+	// три опроса: документ, обновленный документ, 304
+	var recorded [][]string
+	items, err := r.ParseOnce(server.URL, ctx)
+	require.NoError(t, err)
+	recorded = append(recorded, titles(items))
+
+	server.SetBody(strings.Replace(feedWithGuid, "%s", itemWithGuid("2", "Новость 2")+itemWithGuid("1", "Новость 1"), 1))
+	items, err = r.ParseOnce(server.URL, ctx)
+	require.NoError(t, err)
+	recorded = append(recorded, titles(items))
+
+	_, err = r.ParseOnce(server.URL, ctx)
+	assert.ErrorIs(t, err, rss.ErrNoItemsFound)
+	server.Close()
+
+	replay, err := fetchers.NewReplay(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 3, replay.Remaining())
+
+	replayed := rss.New(newMemoryCache(), nil)
+	replayed.RegisterFetcher("http", replay)
+
+	for _, expected := range recorded {
+		items, err := replayed.ParseOnce(server.URL, ctx)
+		require.NoError(t, err)
+		assert.Equal(t, expected, titles(items))
+	}
+	_, err = replayed.ParseOnce(server.URL, ctx)
+	assert.ErrorIs(t, err, rss.ErrNoItemsFound)
+
+	_, err = replayed.ParseOnce(server.URL, ctx)
+	assert.ErrorIs(t, err, fetchers.ErrReplayExhausted)
+}
+
+func TestRssReader_RecordWithoutSecrets(t *testing.T) {
+	body := strings.Replace(feedWithGuid, "%s", itemWithGuid("1", "Новость"), 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "COOKIE"})
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	config := feed.Config{HTTP: feed.HTTPConfig{Query: map[string]string{"k": "APIKEY"}}}
+	ctx := context.Background()
+
+	recorder, err := fetchers.NewRecorder(dir, nil)
+	require.NoError(t, err)
+	r := rss.New(nil, nil)
+	r.SetFeedConfig(server.URL, config)
+	r.WrapFetcher(recorder.Wrap)
+	_, err = r.ParseOnce(server.URL, ctx)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, "000001.json"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "APIKEY")
+	assert.NotContains(t, string(data), "COOKIE")
+
+	// при воспроизведении адрес скрывается так же и находит запись
+	replay, err := fetchers.NewReplay(dir)
+	require.NoError(t, err)
+	replayed := rss.New(nil, nil)
+	replayed.SetFeedConfig(server.URL, config)
+	replayed.RegisterFetcher("http", replay)
+	items, err := replayed.ParseOnce(server.URL, ctx)
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+}
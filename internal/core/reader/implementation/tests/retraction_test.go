@@ -0,0 +1,98 @@
+package implementation_test
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	rss "gafarov/rss-reader/internal/core/reader/implementation"
+)
+
+func TestRssReader_RetractedItem(t *testing.T) {
+	server := newFeedServer(t, strings.Replace(feedWithGuid, "%s",
+		itemWithGuid("3", "Новость 3")+itemWithGuid("2", "Новость 2")+itemWithGuid("1", "Новость 1"), 1))
+
+	r := rss.New(newMemoryCache(), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := r.StartParsing(server.URL, "test", 20*time.Millisecond, ctx)
+	assert.NoError(t, err)
+
+	// вторая новость снята, первая осталась - значит срок хранения в ленте не истек
+	server.SetBody(strings.Replace(feedWithGuid, "%s", itemWithGuid("3", "Новость 3")+itemWithGuid("1", "Новость 1"), 1))
+
+	select {
+	case item := <-r.Output():
+		assert.True(t, item.Retracted)
+		assert.Equal(t, "news-2", item.ID)
+		assert.Equal(t, "https://example.com/news/2", item.Link)
+	case <-time.After(2 * time.Second):
+		t.Fatal("retraction was not emitted")
+	}
+
+	// последний элемент уходит из ленты естественным образом
+	server.SetBody(strings.Replace(feedWithGuid, "%s", itemWithGuid("3", "Новость 3"), 1))
+
+	select {
+	case item := <-r.Output():
+		t.Fatalf("unexpected item %q retracted=%v", item.ID, item.Retracted)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	// возвращенный материал отправляется заново
+	server.SetBody(strings.Replace(feedWithGuid, "%s", itemWithGuid("3", "Новость 3")+itemWithGuid("2", "Новость 2"), 1))
+
+	select {
+	case item := <-r.Output():
+		assert.False(t, item.Retracted)
+		assert.Equal(t, "news-2", item.ID)
+	case <-time.After(2 * time.Second):
+		t.Fatal("restored item was not emitted")
+	}
+
+	assert.NoError(t, r.Stop())
+}
+
+func TestRssReader_RetractionRetriedWhenOutputFull(t *testing.T) {
+	server := newFeedServer(t, strings.Replace(feedWithGuid, "%s",
+		itemWithGuid("3", "Новость 3")+itemWithGuid("2", "Новость 2")+itemWithGuid("1", "Новость 1"), 1))
+
+	r := rss.New(newMemoryCache(), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := r.StartParsing(server.URL, "test", 20*time.Millisecond, ctx)
+	assert.NoError(t, err)
+
+	// новые материалы занимают весь выходной канал, и событию о снятии места не остается
+	var fresh strings.Builder
+	for i := 1000; i < 1500; i++ {
+		fresh.WriteString(itemWithGuid(strconv.Itoa(i), "Новость"))
+	}
+	server.SetBody(strings.Replace(feedWithGuid, "%s", fresh.String()+itemWithGuid("3", "Новость 3")+itemWithGuid("1", "Новость 1"), 1))
+
+	assert.Eventually(t, func() bool { return len(r.Output()) == cap(r.Output()) }, 2*time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+
+	for range cap(r.Output()) {
+		item := <-r.Output()
+		assert.False(t, item.Retracted)
+	}
+
+	select {
+	case item := <-r.Output():
+		assert.True(t, item.Retracted)
+		assert.Equal(t, "news-2", item.ID)
+	case <-time.After(2 * time.Second):
+		t.Fatal("retraction was not emitted after output was drained")
+	}
+
+	assert.NoError(t, r.Stop())
+}
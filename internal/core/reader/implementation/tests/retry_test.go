@@ -0,0 +1,228 @@
+package implementation_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	rss "gafarov/rss-reader/internal/core/reader/implementation"
+	"gafarov/rss-reader/internal/model/feed"
+)
+
+// flakyServer отвечает статусом из status, пока тот не вернет 200
+type flakyServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	status   func(request int) (int, http.Header)
+	requests int
+}
+
+func newFlakyServer(t *testing.T, status func(request int) (int, http.Header)) *flakyServer {
+	body := strings.Replace(feedWithGuid, "%s", itemWithGuid("1", "Новость"), 1)
+	s := &flakyServer{status: status}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s.mu.Lock()
+		s.requests++
+		code, header := s.status(s.requests)
+		s.mu.Unlock()
+
+		for key, values := range header {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(code)
+		if code == http.StatusOK {
+			_, _ = w.Write([]byte(body))
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *flakyServer) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func (s *flakyServer) SetStatus(status func(request int) (int, http.Header)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+var fastRetry = feed.RetryPolicy{Attempts: 3, BaseDelay: 10 * time.Millisecond, MaxDelay: 100 * time.Millisecond}
+
+func TestRssReader_RetryTransientErrors(t *testing.T) {
+	server := newFlakyServer(t, func(request int) (int, http.Header) {
+		if request < 3 {
+			return http.StatusBadGateway, nil
+		}
+		return http.StatusOK, nil
+	})
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetFeedConfig(server.URL, feed.Config{Retry: fastRetry})
+
+	items, err := r.ParseOnce(server.URL, context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, 3, server.Requests())
+}
+
+func TestRssReader_RetryAfter(t *testing.T) {
+	server := newFlakyServer(t, func(request int) (int, http.Header) {
+		if request == 1 {
+			return http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}}
+		}
+		return http.StatusOK, nil
+	})
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetFeedConfig(server.URL, feed.Config{Retry: feed.RetryPolicy{Attempts: 2, BaseDelay: 10 * time.Millisecond, MaxDelay: 2 * time.Second}})
+
+	// Retry-After важнее экспоненциальной задержки
+	start := time.Now()
+	_, err := r.ParseOnce(server.URL, context.Background())
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+
+	server = newFlakyServer(t, func(request int) (int, http.Header) {
+		return http.StatusServiceUnavailable, http.Header{"Retry-After": {"120"}}
+	})
+	r.SetFeedConfig(server.URL, feed.Config{Retry: fastRetry})
+
+	// ожидание дольше MaxDelay откладывается до следующего опроса
+	_, err = r.ParseOnce(server.URL, context.Background())
+	var statusErr *rss.HTTPStatusError
+	if assert.True(t, errors.As(err, &statusErr)) {
+		assert.Equal(t, 120*time.Second, statusErr.RetryAfter)
+	}
+	assert.Equal(t, 1, server.Requests())
+}
+
+func TestRssReader_NoRetryOnClientError(t *testing.T) {
+	server := newFlakyServer(t, func(request int) (int, http.Header) {
+		return http.StatusNotFound, nil
+	})
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetFeedConfig(server.URL, feed.Config{Retry: fastRetry})
+
+	_, err := r.ParseOnce(server.URL, context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 1, server.Requests())
+}
+
+func TestRssReader_PollingBackoff(t *testing.T) {
+	server := newFlakyServer(t, func(request int) (int, http.Header) {
+		return http.StatusOK, nil
+	})
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetFeedConfig(server.URL, feed.Config{
+		Retry:           feed.RetryPolicy{Attempts: 1},
+		MaxPollInterval: 200 * time.Millisecond,
+		Breaker:         feed.BreakerPolicy{FailureThreshold: 100},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := r.StartParsing(server.URL, "test", 10*time.Millisecond, ctx)
+	assert.NoError(t, err)
+
+	server.SetStatus(func(request int) (int, http.Header) {
+		return http.StatusInternalServerError, nil
+	})
+	before := server.Requests()
+	time.Sleep(500 * time.Millisecond)
+	failed := server.Requests() - before
+	// без отката за это время прошло бы около 50 запросов
+	assert.Less(t, failed, 15)
+
+	server.SetStatus(func(request int) (int, http.Header) {
+		return http.StatusOK, nil
+	})
+	time.Sleep(250 * time.Millisecond)
+	before = server.Requests()
+	time.Sleep(200 * time.Millisecond)
+	assert.Greater(t, server.Requests()-before, 5)
+
+	assert.NoError(t, r.Stop())
+}
+
+func TestRssReader_RetryDroppedConnection(t *testing.T) {
+	body := strings.Replace(feedWithGuid, "%s", itemWithGuid("1", "Новость"), 1)
+	var (
+		mu       sync.Mutex
+		requests int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		requests++
+		first := requests == 1
+		mu.Unlock()
+
+		if first {
+			// соединение обрывается до ответа
+			conn, _, err := http.NewResponseController(w).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetFeedConfig(server.URL, feed.Config{Retry: fastRetry})
+
+	items, err := r.ParseOnce(server.URL, context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 2, requests)
+}
+
+func TestRssReader_NoRetryOnPermanentErrors(t *testing.T) {
+	var connections atomic.Int32
+	tlsServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	tlsServer.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	tlsServer.StartTLS()
+	t.Cleanup(tlsServer.Close)
+
+	slowRetry := feed.RetryPolicy{Attempts: 3, BaseDelay: time.Second, MaxDelay: time.Second}
+
+	// сертификат тестового сервера не подписан доверенным центром
+	r := rss.New(newMemoryCache(), nil)
+	r.SetFeedConfig(tlsServer.URL, feed.Config{Retry: slowRetry})
+
+	start := time.Now()
+	_, err := r.ParseOnce(tlsServer.URL, context.Background())
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, int32(1), connections.Load())
+
+	url := "gopher://example.com/feed"
+	r.SetFeedConfig(url, feed.Config{Retry: slowRetry})
+
+	start = time.Now()
+	_, err = r.ParseOnce(url, context.Background())
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
@@ -0,0 +1,136 @@
+package implementation_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fetchers "gafarov/rss-reader/internal/core/fetcher/implementation"
+	rss "gafarov/rss-reader/internal/core/reader/implementation"
+	"gafarov/rss-reader/internal/model/feed"
+)
+
+func writeFeed(t *testing.T, path string, items ...string) {
+	require.NoError(t, os.WriteFile(path, []byte(strings.Replace(feedWithGuid, "%s", strings.Join(items, ""), 1)), 0o644))
+}
+
+func TestRssReader_FileSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feed.xml")
+	writeFeed(t, path, itemWithGuid("1", "Новость"))
+
+	r := rss.New(newMemoryCache(), nil)
+	r.RegisterFetcher("file", fetchers.NewFile())
+	url := "file://" + path
+	ctx := context.Background()
+
+	items, err := r.ParseOnce(url, ctx)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Новость", items[0].Title)
+
+	// файл не менялся
+	_, err = r.ParseOnce(url, ctx)
+	assert.ErrorIs(t, err, rss.ErrNoItemsFound)
+
+	channel, err := r.GetChannel(url, ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Feed", channel.Title)
+
+	_, err = r.ParseOnce("file://"+path+".missing", ctx)
+	var statusErr *rss.HTTPStatusError
+	assert.ErrorAs(t, err, &statusErr)
+}
+
+func TestRssReader_DirectorySource(t *testing.T) {
+	dir := t.TempDir()
+	url := "dir://" + dir
+
+	r := rss.New(newMemoryCache(), nil)
+	r.RegisterFetcher("dir", fetchers.NewDirectory())
+	r.SetFeedConfig(url, feed.Config{EmitExisting: true})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// пустой каталог не считается ошибкой
+	_, err := r.GetChannel(url, ctx)
+	assert.ErrorIs(t, err, rss.ErrNoItemsFound)
+
+	writeFeed(t, filepath.Join(dir, "001.xml"), itemWithGuid("1", "Новость 1"))
+	writeFeed(t, filepath.Join(dir, "002.xml"), itemWithGuid("2", "Новость 2"), itemWithGuid("1", "Новость 1"))
+
+	require.NoError(t, r.StartParsing(url, "drop", 20*time.Millisecond, ctx))
+
+	var titles []string
+	timeout := time.After(2 * time.Second)
+	for len(titles) < 2 {
+		select {
+		case item := <-r.Output():
+			titles = append(titles, item.Title)
+		case <-timeout:
+			t.Fatalf("items not received: %v", titles)
+		}
+	}
+	assert.Equal(t, []string{"Новость 1", "Новость 2"}, titles)
+
+	writeFeed(t, filepath.Join(dir, "003.xml"), itemWithGuid("3", "Новость 3"))
+	select {
+	case item := <-r.Output():
+		assert.Equal(t, "Новость 3", item.Title)
+	case <-time.After(2 * time.Second):
+		t.Fatal("item from new file not received")
+	}
+}
+
+func TestRssReader_StdinSource(t *testing.T) {
+	r := rss.New(newMemoryCache(), nil)
+	r.RegisterFetcher("stdin", fetchers.NewStdin(strings.NewReader(
+		strings.Replace(feedWithGuid, "%s", itemWithGuid("1", "Новость"), 1)), 0))
+
+	ctx := context.Background()
+	channel, err := r.GetChannel("stdin://", ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Feed", channel.Title)
+
+	// GetChannel не сдвигает условный запрос ParseOnce
+	items, err := r.ParseOnce("stdin://", ctx)
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+
+	_, err = r.ParseOnce("stdin://", ctx)
+	assert.ErrorIs(t, err, rss.ErrNoItemsFound)
+}
+
+func TestRssReader_DirectorySkipsBrokenFile(t *testing.T) {
+	dir := t.TempDir()
+	url := "dir://" + dir
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "001.xml"), []byte("<rss><channel><item>"), 0o644))
+	writeFeed(t, filepath.Join(dir, "002.xml"), itemWithGuid("2", "Новость 2"))
+
+	r := rss.New(newMemoryCache(), nil)
+	r.RegisterFetcher("dir", fetchers.NewDirectory())
+	ctx := context.Background()
+
+	_, err := r.ParseOnce(url, ctx)
+	var parseErr *rss.ParseError
+	require.ErrorAs(t, err, &parseErr)
+
+	items, err := r.ParseOnce(url, ctx)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Новость 2", items[0].Title)
+}
+
+func TestRssReader_SourcesOptIn(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feed.xml")
+	writeFeed(t, path, itemWithGuid("1", "Новость"))
+
+	r := rss.New(nil, nil)
+	_, err := r.ParseOnce("file://"+path, context.Background())
+	assert.Error(t, err)
+}
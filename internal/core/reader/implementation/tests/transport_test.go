@@ -0,0 +1,166 @@
+package implementation_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	rss "gafarov/rss-reader/internal/core/reader/implementation"
+	"gafarov/rss-reader/internal/model/feed"
+)
+
+func feedHandler() http.Handler {
+	body := strings.Replace(feedWithGuid, "%s", itemWithGuid("1", "Новость"), 1)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(body))
+	})
+}
+
+func writePEM(t *testing.T, name, blockType string, der []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600)
+	assert.NoError(t, err)
+	return path
+}
+
+// writeClientCert создает самоподписанный клиентский сертификат и возвращает пути к PEM
+func writeClientCert(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "rss-reader"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	return writePEM(t, "client.pem", "CERTIFICATE", der), writePEM(t, "client-key.pem", "EC PRIVATE KEY", keyDER)
+}
+
+func noRetry(transport feed.TransportConfig) feed.Config {
+	return feed.Config{
+		Retry: feed.RetryPolicy{Attempts: 1},
+		HTTP:  feed.HTTPConfig{Transport: transport},
+	}
+}
+
+func TestRssReader_TransportCAFile(t *testing.T) {
+	server := httptest.NewTLSServer(feedHandler())
+	t.Cleanup(server.Close)
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetFeedConfig(server.URL, noRetry(feed.TransportConfig{}))
+
+	_, err := r.ParseOnce(server.URL, context.Background())
+	assert.Error(t, err)
+
+	caFile := writePEM(t, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+	r.SetFeedConfig(server.URL, noRetry(feed.TransportConfig{CAFile: caFile}))
+
+	items, err := r.ParseOnce(server.URL, context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+}
+
+func TestRssReader_TransportClientCertificate(t *testing.T) {
+	server := httptest.NewUnstartedServer(feedHandler())
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	caFile := writePEM(t, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetFeedConfig(server.URL, noRetry(feed.TransportConfig{CAFile: caFile}))
+
+	_, err := r.ParseOnce(server.URL, context.Background())
+	assert.Error(t, err)
+
+	certFile, keyFile := writeClientCert(t)
+	r.SetFeedConfig(server.URL, noRetry(feed.TransportConfig{CAFile: caFile, CertFile: certFile, KeyFile: keyFile}))
+
+	_, err = r.ParseOnce(server.URL, context.Background())
+	assert.NoError(t, err)
+}
+
+func TestRssReader_TransportMinTLSVersion(t *testing.T) {
+	server := httptest.NewUnstartedServer(feedHandler())
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	caFile := writePEM(t, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+
+	r := rss.New(newMemoryCache(), nil)
+	r.SetFeedConfig(server.URL, noRetry(feed.TransportConfig{CAFile: caFile, MinTLSVersion: "1.3"}))
+
+	_, err := r.ParseOnce(server.URL, context.Background())
+	assert.Error(t, err)
+
+	r.SetFeedConfig(server.URL, noRetry(feed.TransportConfig{CAFile: caFile, MinTLSVersion: "1.2"}))
+	_, err = r.ParseOnce(server.URL, context.Background())
+	assert.NoError(t, err)
+}
+
+func TestRssReader_TransportProxy(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		proxied []string
+	)
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		proxied = append(proxied, req.URL.String())
+		mu.Unlock()
+		feedHandler().ServeHTTP(w, req)
+	}))
+	t.Cleanup(proxy.Close)
+
+	url := "http://feeds.partner.example/rss"
+	r := rss.New(newMemoryCache(), nil)
+	r.SetFeedConfig(url, noRetry(feed.TransportConfig{ProxyURL: proxy.URL}))
+
+	items, err := r.ParseOnce(url, context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, []string{url}, proxied)
+}
+
+func TestRssReader_TransportInvalidConfig(t *testing.T) {
+	server := httptest.NewServer(feedHandler())
+	t.Cleanup(server.Close)
+
+	r := rss.New(newMemoryCache(), nil)
+	for _, transport := range []feed.TransportConfig{
+		{ProxyURL: "ftp://proxy.example:21"},
+		{MinTLSVersion: "2.0"},
+		{CAFile: filepath.Join(t.TempDir(), "missing.pem")},
+		{CertFile: filepath.Join(t.TempDir(), "missing.pem")},
+	} {
+		r.SetFeedConfig(server.URL, feed.Config{HTTP: feed.HTTPConfig{Transport: transport}})
+		_, err := r.ParseOnce(server.URL, context.Background())
+		assert.ErrorIs(t, err, rss.ErrInvalidConfig)
+	}
+}
@@ -0,0 +1,65 @@
+package implementation_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	rss "gafarov/rss-reader/internal/core/reader/implementation"
+	kafka "gafarov/rss-reader/internal/model/kafka"
+)
+
+const feedWithGuid = `<rss version="2.0"><channel><title>Feed</title>%s</channel></rss>`
+
+func itemWithGuid(n, title string) string {
+	return `<item><guid isPermaLink="false">news-` + n + `</guid><title>` + title + `</title>` +
+		`<link>https://example.com/news/` + n + `</link><description>Текст</description></item>`
+}
+
+func TestRssReader_UpdatedItem(t *testing.T) {
+	server := newFeedServer(t, strings.Replace(feedWithGuid, "%s", itemWithGuid("1", "Новость"), 1))
+
+	r := rss.New(newMemoryCache(), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	items, err := rss.New(newMemoryCache(), nil).ParseOnce(server.URL, ctx)
+	assert.NoError(t, err)
+	previousHash := items[0].ContentHash
+	assert.NotEmpty(t, previousHash)
+
+	err = r.StartParsing(server.URL, "test", 20*time.Millisecond, ctx)
+	assert.NoError(t, err)
+
+	server.SetBody(strings.Replace(feedWithGuid, "%s", itemWithGuid("1", "Новость (обновлено)"), 1))
+
+	select {
+	case item := <-r.Output():
+		assert.Equal(t, "news-1", item.ID)
+		assert.Equal(t, "Новость (обновлено)", item.Title)
+		if assert.NotNil(t, item.Update) {
+			assert.Equal(t, previousHash, item.Update.PreviousHash)
+			assert.Equal(t, []string{"title"}, item.Update.ChangedFields)
+		}
+		assert.NotEqual(t, previousHash, item.ContentHash)
+
+		data, err := json.Marshal(kafka.Message{Event: kafka.EventUpdated, NewsItem: item, Update: item.Update})
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), `"event":"updated"`)
+	case <-time.After(2 * time.Second):
+		t.Fatal("updated item was not emitted")
+	}
+
+	select {
+	case item := <-r.Output():
+		t.Fatalf("unexpected item %q", item.Title)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	assert.NoError(t, r.Stop())
+}
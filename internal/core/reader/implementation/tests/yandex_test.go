@@ -0,0 +1,38 @@
+package implementation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	rss "gafarov/rss-reader/internal/core/reader/implementation"
+	model "gafarov/rss-reader/internal/model/rss"
+)
+
+func TestRssReader_ParseYandexExtensions(t *testing.T) {
+	server := newFixtureServer(t, "yandex.xml", "application/rss+xml")
+	r := rss.New(nil, nil)
+	defer r.Stop()
+
+	items, err := r.ParseOnce(server.URL, context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+
+	item := items[0]
+	assert.Equal(t, "https://example.com/news/1", item.Link)
+	assert.Equal(t, "https://example.com/amp/news/1", item.AmpLink)
+	assert.Equal(t, "https://m.example.com/news/1", item.PdaLink)
+	assert.Equal(t, "society", item.ArticleCategory)
+	assert.Equal(t, "Казань", item.Region)
+	assert.Equal(t, "article", item.Genre)
+	assert.Equal(t, "Полный текст для Яндекс.Новостей", item.YandexFullText)
+	assert.Equal(t, "Полный текст для Яндекс.Новостей", item.Fulltext)
+	assert.Equal(t, "https://example.com/news/1?utm_source=turbo", item.TurboSource)
+	assert.Equal(t, "Новость дня", item.TurboTopic)
+	assert.Equal(t, "<header><h1>Новость дня</h1></header><p>Текст</p>", item.TurboContent)
+	assert.Equal(t, []model.RelatedLink{
+		{URL: "https://example.com/news/2", Image: "https://example.com/news/2.jpg", Title: "Вторая новость"},
+		{URL: "https://example.com/news/3", Title: "Третья новость"},
+	}, item.Related)
+}
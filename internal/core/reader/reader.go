@@ -2,6 +2,7 @@ package reader
 
 import (
 	"context"
+	"gafarov/rss-reader/internal/model/feed"
 	"gafarov/rss-reader/internal/model/rss"
 	"time"
 )
@@ -10,6 +11,7 @@ type IReader interface {
 	StartParsing(url, name string, delay time.Duration, ctx context.Context) error
 	ParseOnce(url string, ctx context.Context) ([]*rss.Item, error)
 	GetChannel(url string, ctx context.Context) (*rss.Channel, error)
+	SetFeedConfig(url string, config feed.Config)
 	Output() <-chan rss.Item
 	Stop() error
 }
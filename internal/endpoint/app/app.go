@@ -4,6 +4,7 @@ import (
 	"context"
 	"gafarov/rss-reader/internal/core/kafka"
 	"gafarov/rss-reader/internal/core/reader"
+	"gafarov/rss-reader/internal/model/feed"
 	"os"
 	"strings"
 	"time"
@@ -26,7 +27,7 @@ func New(reader reader.IReader, kafka kafka.IKafka, logger *zap.Logger) *App {
 }
 
 func (a *App) Run(url, name, code string, delay time.Duration, ctx context.Context) error {
-	a.logger.Info("Starting app", zap.String("url", url), zap.String("code", code), zap.Duration("delay", delay))
+	a.logger.Info("Starting app", zap.String("url", feed.RedactURL(url)), zap.String("code", code), zap.Duration("delay", delay))
 	output := a.reader.Output()
 	a.reader.StartParsing(url, name, delay, ctx)
 	defer a.reader.Stop()
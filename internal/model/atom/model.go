@@ -0,0 +1,73 @@
+package atom
+
+import "encoding/xml"
+
+type Link struct {
+	Href   string `xml:"href,attr" json:"href"`
+	Rel    string `xml:"rel,attr" json:"rel"`
+	Type   string `xml:"type,attr" json:"type"`
+	Title  string `xml:"title,attr" json:"title,omitempty"`
+	Length string `xml:"length,attr" json:"length,omitempty"`
+}
+
+type Person struct {
+	Name  string `xml:"name" json:"name"`
+	Email string `xml:"email" json:"email"`
+	URI   string `xml:"uri" json:"uri"`
+}
+
+type Category struct {
+	Term  string `xml:"term,attr" json:"term"`
+	Label string `xml:"label,attr" json:"label"`
+}
+
+type Text struct {
+	Type     string `xml:"type,attr" json:"type"`
+	Body     string `xml:",chardata" json:"body"`
+	InnerXML string `xml:",innerxml" json:"-"`
+}
+
+// Поля Entry и Feed привязаны к пространству имен Atom, чтобы одноименные элементы
+// расширений вроде media:title или dc:title не подменяли их значения
+type Entry struct {
+	ID         string     `xml:"http://www.w3.org/2005/Atom id" json:"id"`
+	Title      Text       `xml:"http://www.w3.org/2005/Atom title" json:"title"`
+	Updated    string     `xml:"http://www.w3.org/2005/Atom updated" json:"updated"`
+	Published  string     `xml:"http://www.w3.org/2005/Atom published" json:"published"`
+	Links      []Link     `xml:"http://www.w3.org/2005/Atom link" json:"links"`
+	Summary    Text       `xml:"http://www.w3.org/2005/Atom summary" json:"summary"`
+	Content    Text       `xml:"http://www.w3.org/2005/Atom content" json:"content"`
+	Authors    []Person   `xml:"http://www.w3.org/2005/Atom author" json:"authors"`
+	Categories []Category `xml:"http://www.w3.org/2005/Atom category" json:"categories"`
+}
+
+type Generator struct {
+	URI     string `xml:"uri,attr" json:"uri"`
+	Version string `xml:"version,attr" json:"version"`
+	Name    string `xml:",chardata" json:"name"`
+}
+
+type Feed struct {
+	XMLName    xml.Name   `xml:"feed" json:"-"`
+	Lang       string     `xml:"http://www.w3.org/XML/1998/namespace lang,attr" json:"lang"`
+	ID         string     `xml:"http://www.w3.org/2005/Atom id" json:"id"`
+	Title      Text       `xml:"http://www.w3.org/2005/Atom title" json:"title"`
+	Subtitle   Text       `xml:"http://www.w3.org/2005/Atom subtitle" json:"subtitle"`
+	Updated    string     `xml:"http://www.w3.org/2005/Atom updated" json:"updated"`
+	Links      []Link     `xml:"http://www.w3.org/2005/Atom link" json:"links"`
+	Authors    []Person   `xml:"http://www.w3.org/2005/Atom author" json:"authors"`
+	Categories []Category `xml:"http://www.w3.org/2005/Atom category" json:"categories"`
+	Generator  Generator  `xml:"http://www.w3.org/2005/Atom generator" json:"generator"`
+	Rights     Text       `xml:"http://www.w3.org/2005/Atom rights" json:"rights"`
+	Icon       string     `xml:"http://www.w3.org/2005/Atom icon" json:"icon"`
+	Logo       string     `xml:"http://www.w3.org/2005/Atom logo" json:"logo"`
+	Entries    []Entry    `xml:"http://www.w3.org/2005/Atom entry" json:"entries"`
+}
+
+// Value возвращает текст элемента с учетом type="xhtml"
+func (t Text) Value() string {
+	if t.Type == "xhtml" {
+		return t.InnerXML
+	}
+	return t.Body
+}
@@ -6,3 +6,31 @@ type LastRecord struct {
 	Guid     string    `json:"guid"`
 	ReadTime time.Time `json:"readTime"`
 }
+
+// Validators - заголовки условного запроса, полученные при последнем чтении ленты
+type Validators struct {
+	ETag         string `json:"etag"`
+	LastModified string `json:"lastModified"`
+	Length       int64  `json:"length"`
+}
+
+// Token - токен доступа OAuth2, общий для лент с одинаковым клиентом
+type Token struct {
+	AccessToken string    `json:"accessToken"`
+	TokenType   string    `json:"tokenType"`
+	Expiry      time.Time `json:"expiry"`
+}
+
+// SnapshotItem - элемент ленты, увиденный при последнем чтении
+type SnapshotItem struct {
+	ID      string     `json:"id"`
+	Title   string     `json:"title"`
+	Link    string     `json:"link"`
+	PubTime *time.Time `json:"pubTime,omitempty"`
+}
+
+// Fingerprint хранится вместе с отметкой об обработке элемента
+type Fingerprint struct {
+	Hash   string            `json:"hash"`
+	Fields map[string]string `json:"fields"`
+}
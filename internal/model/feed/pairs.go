@@ -0,0 +1,45 @@
+package feed
+
+import "strings"
+
+// ParsePairs разбирает заголовки, параметры запроса и cookies из строки вида
+// "X-Api-Key=c2VjcmV0=,Accept=application/rss+xml, application/xml".
+// Имя отделяется первым "=", поэтому значения могут содержать "=" (base64, токены).
+// Часть без "=" или с недопустимым в имени символом продолжает значение предыдущей пары, так сохраняются запятые в значениях.
+// Пары также можно разделять переводом строки
+func ParsePairs(s string) map[string]string {
+	pairs := make(map[string]string)
+	last := ""
+	for line := range strings.Lines(s) {
+		for part := range strings.SplitSeq(strings.TrimRight(line, "\r\n"), ",") {
+			name, value, ok := strings.Cut(part, "=")
+			name = strings.TrimSpace(name)
+			if !ok || !IsToken(name) {
+				if last != "" {
+					pairs[last] += "," + part
+				}
+				continue
+			}
+			pairs[name] = strings.TrimSpace(value)
+			last = name
+		}
+		last = ""
+	}
+	for name, value := range pairs {
+		pairs[name] = strings.TrimSpace(value)
+	}
+	return pairs
+}
+
+// isToken - имя заголовка, параметра или cookie по RFC 9110
+func IsToken(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("!#$%&'*+-.^_`|~", r)) {
+			return false
+		}
+	}
+	return true
+}
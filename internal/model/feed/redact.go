@@ -0,0 +1,58 @@
+package feed
+
+import (
+	"net/url"
+	"strings"
+)
+
+const redacted = "REDACTED"
+
+// Параметры адреса, значения которых не должны попадать в журнал
+var sensitiveParams = []string{
+	"key", "apikey", "api_key", "api-key", "token", "access_token", "auth", "secret",
+	"password", "passwd", "sig", "signature", "session", "sid",
+}
+
+func isSensitive(name string, extra []string) bool {
+	name = strings.ToLower(name)
+	for _, sensitive := range sensitiveParams {
+		if name == sensitive || strings.HasSuffix(name, "_"+sensitive) || strings.HasSuffix(name, "-"+sensitive) {
+			return true
+		}
+	}
+	for _, param := range extra {
+		if strings.EqualFold(name, param) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactURL скрывает пароль и значения секретных параметров адреса.
+// extra - дополнительные имена параметров, например ключи из HTTPConfig.Query
+func RedactURL(raw string, extra ...string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+
+	if u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redacted)
+		}
+	}
+
+	if u.RawQuery != "" {
+		query := u.Query()
+		for name, values := range query {
+			if isSensitive(name, extra) {
+				for i := range values {
+					values[i] = redacted
+				}
+			}
+		}
+		u.RawQuery = query.Encode()
+	}
+
+	return u.String()
+}
@@ -0,0 +1,31 @@
+package feed_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gafarov/rss-reader/internal/model/feed"
+)
+
+func TestParsePairs(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected map[string]string
+	}{
+		{"base64 с дополнением", "X-Api-Key=c2VjcmV0=", map[string]string{"X-Api-Key": "c2VjcmV0="}},
+		{"несколько пар", "X-Api-Key=c2VjcmV0==, X-Partner=realnoe", map[string]string{"X-Api-Key": "c2VjcmV0==", "X-Partner": "realnoe"}},
+		{"запятые в значении", "Accept=application/rss+xml, application/xml;q=0.9,k=v",
+			map[string]string{"Accept": "application/rss+xml, application/xml;q=0.9", "k": "v"}},
+		{"перевод строки", "session=YWJj=\ntoken=a,b", map[string]string{"session": "YWJj=", "token": "a,b"}},
+		{"пустая строка", "", map[string]string{}},
+		{"без имени", "=value", map[string]string{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, feed.ParsePairs(c.input))
+		})
+	}
+}
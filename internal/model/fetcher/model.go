@@ -0,0 +1,19 @@
+package fetcher
+
+import (
+	"net/http"
+	"time"
+)
+
+// Record - сохраненный ответ источника. Тело лежит рядом в файле с расширением .body
+// в том виде, в каком пришло, в том числе сжатым
+type Record struct {
+	// URL без секретов, по нему ответ находится при воспроизведении
+	URL        string      `json:"url"`
+	Time       time.Time   `json:"time"`
+	StatusCode int         `json:"status,omitempty"`
+	Header     http.Header `json:"header,omitempty"`
+	// Error - ошибка запроса вместо ответа, например таймаут
+	Error   string `json:"error,omitempty"`
+	Timeout bool   `json:"timeout,omitempty"`
+}
@@ -0,0 +1,54 @@
+package jsonfeed
+
+type Author struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Avatar string `json:"avatar"`
+}
+
+type Attachment struct {
+	URL               string  `json:"url"`
+	MimeType          string  `json:"mime_type"`
+	Title             string  `json:"title"`
+	SizeInBytes       int64   `json:"size_in_bytes"`
+	DurationInSeconds float64 `json:"duration_in_seconds"`
+}
+
+type Item struct {
+	ID            string       `json:"id"`
+	URL           string       `json:"url"`
+	ExternalURL   string       `json:"external_url"`
+	Title         string       `json:"title"`
+	ContentHTML   string       `json:"content_html"`
+	ContentText   string       `json:"content_text"`
+	Summary       string       `json:"summary"`
+	Image         string       `json:"image"`
+	BannerImage   string       `json:"banner_image"`
+	DatePublished string       `json:"date_published"`
+	DateModified  string       `json:"date_modified"`
+	Authors       []Author     `json:"authors"`
+	Author        *Author      `json:"author"`
+	Tags          []string     `json:"tags"`
+	Language      string       `json:"language"`
+	Attachments   []Attachment `json:"attachments"`
+}
+
+type Hub struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+type Feed struct {
+	Version     string   `json:"version"`
+	Title       string   `json:"title"`
+	HomePageURL string   `json:"home_page_url"`
+	FeedURL     string   `json:"feed_url"`
+	Description string   `json:"description"`
+	Icon        string   `json:"icon"`
+	Favicon     string   `json:"favicon"`
+	Language    string   `json:"language"`
+	Authors     []Author `json:"authors"`
+	Author      *Author  `json:"author"`
+	Hubs        []Hub    `json:"hubs"`
+	Items       []Item   `json:"items"`
+}
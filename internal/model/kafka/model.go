@@ -4,18 +4,39 @@ import (
 	"gafarov/rss-reader/internal/model/rss"
 )
 
+const (
+	EventCreated   = "created"
+	EventUpdated   = "updated"
+	EventRetracted = "retracted"
+)
+
 type Message struct {
-	NewsItem  rss.Item `json:"newsItem"`
-	Channel   Channel  `json:"channel"`
-	IsTesting bool     `json:"isTesting"`
+	Event     string      `json:"event"`
+	NewsItem  rss.Item    `json:"newsItem"`
+	Update    *rss.Update `json:"update,omitempty"`
+	Channel   Channel     `json:"channel"`
+	IsTesting bool        `json:"isTesting"`
 }
 
 type Channel struct {
-	Title       string `json:"title"`
-	Link        string `json:"link"`
-	Description string `json:"description"`
-	Language    string `json:"language"`
-	Code        string `json:"codes"`
+	Title          string    `json:"title"`
+	Link           string    `json:"link"`
+	Description    string    `json:"description"`
+	Language       string    `json:"language"`
+	Image          rss.Image `json:"image"`
+	TTL            string    `json:"ttl"`
+	LastBuildDate  string    `json:"lastBuildDate"`
+	PubDate        string    `json:"pubDate"`
+	Generator      string    `json:"generator"`
+	Copyright      string    `json:"copyright"`
+	ManagingEditor string    `json:"managingEditor"`
+	WebMaster      string    `json:"webMaster"`
+	Categories     []string  `json:"categories"`
+	SelfURL        string    `json:"selfUrl"`
+	HubURLs        []string  `json:"hubUrls"`
+	Code           string    `json:"codes"`
+	// Extra - нераспознанные элементы канала, как и у элементов ленты
+	Extra map[string][]string `json:"extra,omitempty"`
 }
 
 func (c *Channel) ConvertFromRSS(channel *rss.Channel, code string) *Channel {
@@ -23,6 +44,18 @@ func (c *Channel) ConvertFromRSS(channel *rss.Channel, code string) *Channel {
 	c.Link = channel.Link
 	c.Description = channel.Description
 	c.Language = channel.Language
+	c.Image = channel.Image
+	c.TTL = channel.TTL
+	c.LastBuildDate = channel.LastBuildDate
+	c.PubDate = channel.PubDate
+	c.Generator = channel.Generator
+	c.Copyright = channel.Copyright
+	c.ManagingEditor = channel.ManagingEditor
+	c.WebMaster = channel.WebMaster
+	c.Categories = channel.Categories
+	c.SelfURL = channel.SelfURL
+	c.HubURLs = channel.HubURLs
+	c.Extra = channel.Extra
 	c.Code = code
 	return c
 }
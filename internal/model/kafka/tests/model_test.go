@@ -1,8 +1,10 @@
 package kafka_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/stretchr/testify/assert"
@@ -19,6 +21,21 @@ func TestMessage_ConvertFromRSS(t *testing.T) {
 				Link:        gofakeit.URL(),
 				Description: gofakeit.Paragraph(3, 5, 15, " "),
 				Language:    gofakeit.Language(),
+				Image: rss.Image{
+					URL:   gofakeit.URL(),
+					Title: gofakeit.Sentence(2),
+					Link:  gofakeit.URL(),
+				},
+				TTL:            fmt.Sprint(gofakeit.Number(1, 120)),
+				LastBuildDate:  gofakeit.Date().Format(time.RFC1123Z),
+				PubDate:        gofakeit.Date().Format(time.RFC1123Z),
+				Generator:      gofakeit.AppName(),
+				Copyright:      gofakeit.Company(),
+				ManagingEditor: gofakeit.Email(),
+				WebMaster:      gofakeit.Email(),
+				Categories:     []string{gofakeit.Word(), gofakeit.Word()},
+				SelfURL:        gofakeit.URL(),
+				HubURLs:        []string{gofakeit.URL()},
 			}
 
 			testingChannel := &kafka.Channel{}
@@ -28,8 +45,32 @@ func TestMessage_ConvertFromRSS(t *testing.T) {
 			assert.Equal(t, channel.Link, testingChannel.Link, "Link должен совпасть")
 			assert.Equal(t, channel.Description, testingChannel.Description, "Description должен совпасть")
 			assert.Equal(t, channel.Language, testingChannel.Language, "Language должен совпасть")
+			assert.Equal(t, channel.Image, testingChannel.Image, "Image должен совпасть")
+			assert.Equal(t, channel.TTL, testingChannel.TTL, "TTL должен совпасть")
+			assert.Equal(t, channel.LastBuildDate, testingChannel.LastBuildDate, "LastBuildDate должен совпасть")
+			assert.Equal(t, channel.PubDate, testingChannel.PubDate, "PubDate должен совпасть")
+			assert.Equal(t, channel.Generator, testingChannel.Generator, "Generator должен совпасть")
+			assert.Equal(t, channel.Copyright, testingChannel.Copyright, "Copyright должен совпасть")
+			assert.Equal(t, channel.ManagingEditor, testingChannel.ManagingEditor, "ManagingEditor должен совпасть")
+			assert.Equal(t, channel.WebMaster, testingChannel.WebMaster, "WebMaster должен совпасть")
+			assert.Equal(t, channel.Categories, testingChannel.Categories, "Categories должны совпасть")
+			assert.Equal(t, channel.SelfURL, testingChannel.SelfURL, "SelfURL должен совпасть")
+			assert.Equal(t, channel.HubURLs, testingChannel.HubURLs, "HubURLs должны совпасть")
 			assert.Equal(t, testingChannel, result, "Объекты должны совпасть")
 			assert.Equal(t, "testCode", result.Code, "Коды должны совпасть")
 		})
 	}
 }
+
+func TestMessage_ChannelExtra(t *testing.T) {
+	channel := &rss.Channel{Title: gofakeit.Sentence(4)}
+	channel.Extra = map[string][]string{"{http://news.yandex.ru}logo": {gofakeit.URL()}}
+
+	testingChannel := &kafka.Channel{}
+	testingChannel.ConvertFromRSS(channel, "testCode")
+	assert.Equal(t, channel.Extra, testingChannel.Extra, "Extra должен совпасть")
+
+	data, err := json.Marshal(kafka.Message{Channel: *testingChannel})
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"extra":{"{http://news.yandex.ru}logo"`, "Extra канала должен попасть в сообщение")
+}
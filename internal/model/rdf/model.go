@@ -0,0 +1,45 @@
+package rdf
+
+import "encoding/xml"
+
+type Enclosure struct {
+	URL    string `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# resource,attr" json:"url"`
+	Type   string `xml:"http://purl.oclc.org/net/rss_2.0/enc# type,attr" json:"type"`
+	Length string `xml:"http://purl.oclc.org/net/rss_2.0/enc# length,attr" json:"length"`
+}
+
+type Item struct {
+	About       string      `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# about,attr" json:"about"`
+	Title       string      `xml:"title" json:"title"`
+	Link        string      `xml:"link" json:"link"`
+	Description string      `xml:"description" json:"description"`
+	Date        string      `xml:"http://purl.org/dc/elements/1.1/ date" json:"date"`
+	Creator     string      `xml:"http://purl.org/dc/elements/1.1/ creator" json:"creator"`
+	Subject     []string    `xml:"http://purl.org/dc/elements/1.1/ subject" json:"subject"`
+	Fulltext    string      `xml:"http://purl.org/rss/1.0/modules/content/ encoded" json:"fullText"`
+	Enclosures  []Enclosure `xml:"http://purl.oclc.org/net/rss_2.0/enc# enclosure" json:"enclosures"`
+}
+
+type Channel struct {
+	About       string `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# about,attr" json:"about"`
+	Title       string `xml:"title" json:"title"`
+	Link        string `xml:"link" json:"link"`
+	Description string `xml:"description" json:"description"`
+	Language    string `xml:"http://purl.org/dc/elements/1.1/ language" json:"language"`
+	Date        string `xml:"http://purl.org/dc/elements/1.1/ date" json:"date"`
+	Publisher   string `xml:"http://purl.org/dc/elements/1.1/ publisher" json:"publisher"`
+	Rights      string `xml:"http://purl.org/dc/elements/1.1/ rights" json:"rights"`
+}
+
+type Image struct {
+	URL   string `xml:"url" json:"url"`
+	Title string `xml:"title" json:"title"`
+	Link  string `xml:"link" json:"link"`
+}
+
+type RDF struct {
+	XMLName xml.Name `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# RDF" json:"-"`
+	Channel Channel  `xml:"channel" json:"channel"`
+	Image   Image    `xml:"image" json:"image"`
+	Items   []Item   `xml:"item" json:"items"`
+}
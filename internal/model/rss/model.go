@@ -1,37 +1,211 @@
 package rss
 
 import (
+	"encoding/json"
 	"encoding/xml"
+	"strings"
 	"time"
 )
 
 type Enclosure struct {
-	URL  string `xml:"url,attr" json:"url"`
-	Type string `xml:"type,attr" json:"type"`
+	URL    string `xml:"url,attr" json:"url"`
+	Type   string `xml:"type,attr" json:"type"`
+	Length string `xml:"length,attr" json:"length"`
+}
+
+type Thumbnail struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+type Media struct {
+	URL         string      `json:"url"`
+	Type        string      `json:"type"`
+	Medium      string      `json:"medium"`
+	Length      int64       `json:"length"`
+	Width       int         `json:"width"`
+	Height      int         `json:"height"`
+	Duration    int         `json:"duration"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	Thumbnails  []Thumbnail `json:"thumbnails"`
+}
+
+// Элементы Media RSS (http://search.yahoo.com/mrss/) в том виде, как они лежат в ленте
+
+type MediaThumbnail struct {
+	URL    string `xml:"url,attr"`
+	Width  string `xml:"width,attr"`
+	Height string `xml:"height,attr"`
+}
+
+type MediaContent struct {
+	URL         string           `xml:"url,attr"`
+	Type        string           `xml:"type,attr"`
+	Medium      string           `xml:"medium,attr"`
+	FileSize    string           `xml:"fileSize,attr"`
+	Width       string           `xml:"width,attr"`
+	Height      string           `xml:"height,attr"`
+	Duration    string           `xml:"duration,attr"`
+	Title       string           `xml:"http://search.yahoo.com/mrss/ title"`
+	Description string           `xml:"http://search.yahoo.com/mrss/ description"`
+	Thumbnails  []MediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+}
+
+type MediaGroup struct {
+	Contents    []MediaContent   `xml:"http://search.yahoo.com/mrss/ content"`
+	Title       string           `xml:"http://search.yahoo.com/mrss/ title"`
+	Description string           `xml:"http://search.yahoo.com/mrss/ description"`
+	Thumbnails  []MediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+}
+
+// MediaRSS и DublinCore встраиваются в Item первыми: encoding/xml сопоставляет
+// элемент с первым подходящим полем, а поля без пространства имен подходят к любому
+type MediaRSS struct {
+	MediaContents    []MediaContent   `xml:"http://search.yahoo.com/mrss/ content" json:"-"`
+	MediaGroups      []MediaGroup     `xml:"http://search.yahoo.com/mrss/ group" json:"-"`
+	MediaThumbnails  []MediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail" json:"-"`
+	MediaTitle       string           `xml:"http://search.yahoo.com/mrss/ title" json:"-"`
+	MediaDescription string           `xml:"http://search.yahoo.com/mrss/ description" json:"-"`
+}
+
+type DublinCore struct {
+	DCCreator string `xml:"http://purl.org/dc/elements/1.1/ creator" json:"-"`
+	DCDate    string `xml:"http://purl.org/dc/elements/1.1/ date" json:"-"`
+}
+
+type ITunesImage struct {
+	Href string `xml:"href,attr" json:"href"`
+}
+
+type ITunes struct {
+	ITunesTitle       string      `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd title" json:"title"`
+	ITunesAuthor      string      `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd author" json:"author"`
+	ITunesSubtitle    string      `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd subtitle" json:"subtitle"`
+	ITunesSummary     string      `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd summary" json:"summary"`
+	ITunesImage       ITunesImage `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image" json:"image"`
+	ITunesDuration    string      `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration" json:"duration"`
+	ITunesExplicit    string      `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd explicit" json:"explicit"`
+	ITunesEpisode     string      `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd episode" json:"episode"`
+	ITunesSeason      string      `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd season" json:"season"`
+	ITunesEpisodeType string      `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd episodeType" json:"episodeType"`
+	ITunesKeywords    string      `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd keywords" json:"keywords"`
+}
+
+// Element хранит дочерний элемент, не сопоставленный ни с одним полем
+type Element struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Text     string     `xml:",chardata"`
+	InnerXML string     `xml:",innerxml"`
+}
+
+// Unknown собирает нераспознанные элементы и атрибуты, Extra - их значения
+// по ключу "{namespace}local" (атрибуты - "@{namespace}local")
+type Unknown struct {
+	UnknownElements []Element           `xml:",any" json:"-"`
+	UnknownAttrs    []xml.Attr          `xml:",any,attr" json:"-"`
+	Extra           map[string][]string `xml:"-" json:"extra,omitempty"`
+}
+
+// Guid в JSON сериализуется строкой, как и до появления isPermaLink
+type Guid struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink string `xml:"isPermaLink,attr"`
+}
+
+func (g Guid) MarshalJSON() ([]byte, error) {
+	return json.Marshal(g.Value)
+}
+
+func (g *Guid) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &g.Value)
+}
+
+// PermaLink сообщает, является ли guid постоянной ссылкой на материал
+func (g Guid) PermaLink() bool {
+	return !strings.EqualFold(strings.TrimSpace(g.IsPermaLink), "false")
+}
+
+// Update описывает правку ранее отправленного элемента
+type Update struct {
+	PreviousHash  string   `json:"previousHash"`
+	ChangedFields []string `json:"changedFields"`
+}
+
+type RelatedLink struct {
+	URL   string `xml:"url,attr" json:"url"`
+	Image string `xml:"img,attr" json:"image"`
+	Title string `xml:",chardata" json:"title"`
 }
 
 type Item struct {
-	Title           string     `xml:"title" json:"title"`
-	PubDate         string     `xml:"pubDate" json:"pubDate"`
-	PubTimeParsed   *time.Time `xml:"-" json:"pubTimeParsed"`
-	Category        []string   `xml:"category" json:"category"`
-	ArticleCategory string     `xml:"category-article" json:"articleCategory"`
-	Link            string     `xml:"link" json:"link"`
-	AmpLink         string     `xml:"amplink" json:"ampLink"`
-	Description     string     `xml:"description" json:"description"`
-	Fulltext        string     `xml:"http://purl.org/rss/1.0/modules/content/ encoded" json:"fullText"`
-	Enclosure       Enclosure  `xml:"enclosure" json:"enclosure"`
-	Guid            string     `xml:"guid" json:"guid"`
-	Region          string     `xml:"region" json:"region"`
-	Author          string     `xml:"author" json:"author"`
+	MediaRSS
+	DublinCore
+	ITunes          `json:"itunes"`
+	Title           string        `xml:"title" json:"title"`
+	PubDate         string        `xml:"pubDate" json:"pubDate"`
+	PubTimeParsed   *time.Time    `xml:"-" json:"pubTimeParsed"`
+	Category        []string      `xml:"category" json:"category"`
+	ArticleCategory string        `xml:"category-article" json:"articleCategory"`
+	Link            string        `xml:"link" json:"link"`
+	AmpLink         string        `xml:"amplink" json:"ampLink"`
+	Description     string        `xml:"description" json:"description"`
+	Fulltext        string        `xml:"http://purl.org/rss/1.0/modules/content/ encoded" json:"fullText"`
+	Enclosure       Enclosure     `xml:"enclosure" json:"enclosure"`
+	Guid            Guid          `xml:"guid" json:"guid"`
+	ID              string        `xml:"-" json:"id"`
+	ContentHash     string        `xml:"-" json:"contentHash"`
+	Update          *Update       `xml:"-" json:"-"`
+	Retracted       bool          `xml:"-" json:"-"`
+	Region          string        `xml:"region" json:"region"`
+	PdaLink         string        `xml:"pdalink" json:"pdaLink"`
+	Genre           string        `xml:"http://news.yandex.ru genre" json:"genre"`
+	YandexFullText  string        `xml:"http://news.yandex.ru full-text" json:"yandexFullText"`
+	Related         []RelatedLink `xml:"related>link" json:"related"`
+	TurboContent    string        `xml:"http://turbo.yandex.ru content" json:"turboContent"`
+	TurboSource     string        `xml:"http://turbo.yandex.ru source" json:"turboSource"`
+	TurboTopic      string        `xml:"http://turbo.yandex.ru topic" json:"turboTopic"`
+	Author          string        `xml:"author" json:"author"`
+	Media           []Media       `xml:"-" json:"media"`
+	Unknown
+}
+
+type Image struct {
+	URL    string `xml:"url" json:"url"`
+	Title  string `xml:"title" json:"title"`
+	Link   string `xml:"link" json:"link"`
+	Width  string `xml:"width" json:"width"`
+	Height string `xml:"height" json:"height"`
+}
+
+type AtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
 }
 
 type Channel struct {
-	Title       string `xml:"title" json:"title"`
-	Link        string `xml:"link" json:"link"`
-	Description string `xml:"description" json:"description"`
-	Language    string `xml:"language" json:"language"`
-	Items       []Item `xml:"item" json:"items"`
+	AtomLinks      []AtomLink  `xml:"http://www.w3.org/2005/Atom link" json:"-"`
+	ITunesImage    ITunesImage `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image" json:"-"`
+	Title          string      `xml:"title" json:"title"`
+	Link           string      `xml:"link" json:"link"`
+	Description    string      `xml:"description" json:"description"`
+	Language       string      `xml:"language" json:"language"`
+	Image          Image       `xml:"image" json:"image"`
+	TTL            string      `xml:"ttl" json:"ttl"`
+	LastBuildDate  string      `xml:"lastBuildDate" json:"lastBuildDate"`
+	PubDate        string      `xml:"pubDate" json:"pubDate"`
+	Generator      string      `xml:"generator" json:"generator"`
+	Copyright      string      `xml:"copyright" json:"copyright"`
+	ManagingEditor string      `xml:"managingEditor" json:"managingEditor"`
+	WebMaster      string      `xml:"webMaster" json:"webMaster"`
+	Categories     []string    `xml:"category" json:"categories"`
+	SelfURL        string      `xml:"-" json:"selfUrl"`
+	HubURLs        []string    `xml:"-" json:"hubUrls"`
+	Items          []Item      `xml:"item" json:"items"`
+	Unknown
 }
 
 type Rss struct {
@@ -2,10 +2,15 @@ package app
 
 import (
 	"context"
+	"fmt"
 	cache "gafarov/rss-reader/internal/core/cache/redis"
+	fetchers "gafarov/rss-reader/internal/core/fetcher/implementation"
 	kafka "gafarov/rss-reader/internal/core/kafka/implementation"
+	"gafarov/rss-reader/internal/core/parser"
 	reader "gafarov/rss-reader/internal/core/reader/implementation"
 	endpoint "gafarov/rss-reader/internal/endpoint/app"
+	"gafarov/rss-reader/internal/model/feed"
+	"os"
 	"time"
 
 	"go.uber.org/zap"
@@ -17,37 +22,105 @@ type RedisData struct {
 }
 
 type KafkaData struct {
-	Topic string
-	Addr  []string
+	Topic      string
+	Addr       []string
+	Tombstones bool
+}
+
+type ReaderData struct {
+	Strict  bool
+	Promote map[string]string
+	Limits  reader.Limits
+	// RecordDir - каталог для записи ответов лент, ReplayDir - каталог записанных ответов,
+	// которые отдаются вместо запросов к http и https
+	RecordDir string
+	ReplayDir string
+	// Sources подключает источники кроме HTTP: "file", "dir", "stdin"
+	Sources []string
 }
 
 type App struct {
 	endpoint *endpoint.App
+	reader   *reader.RssReader
 	logger   *zap.Logger
 }
 
-func New(redisData RedisData, kafkaData KafkaData, logger *zap.Logger) (*App, error) {
+func New(redisData RedisData, kafkaData KafkaData, readerData ReaderData, logger *zap.Logger) (*App, error) {
 	cache, err := cache.New(redisData.Host, redisData.Password, logger)
 	if err != nil {
 		logger.Error("failed to create cache", zap.Error(err))
 		return nil, err
 	}
 	reader := reader.New(cache, logger)
+	reader.SetParserOptions(parser.Options{
+		Strict:  readerData.Strict,
+		Promote: readerData.Promote,
+	})
+	reader.SetLimits(readerData.Limits)
+	for _, source := range readerData.Sources {
+		switch source {
+		case "file":
+			reader.RegisterFetcher(source, fetchers.NewFile())
+		case "dir":
+			reader.RegisterFetcher(source, fetchers.NewDirectory())
+		case "stdin":
+			reader.RegisterFetcher(source, fetchers.NewStdin(os.Stdin, 0))
+		default:
+			err := fmt.Errorf("unknown feed source %q", source)
+			logger.Error("failed to configure reader", zap.Error(err))
+			return nil, err
+		}
+	}
+	if readerData.ReplayDir != "" {
+		replay, err := fetchers.NewReplay(readerData.ReplayDir)
+		if err != nil {
+			logger.Error("failed to load recorded responses", zap.Error(err))
+			return nil, err
+		}
+		reader.RegisterFetcher("http", replay)
+		reader.RegisterFetcher("https", replay)
+	}
+	if readerData.RecordDir != "" {
+		recorder, err := fetchers.NewRecorder(readerData.RecordDir, logger)
+		if err != nil {
+			logger.Error("failed to create recorder", zap.Error(err))
+			return nil, err
+		}
+		reader.WrapFetcher(recorder.Wrap)
+	}
 	kafka, err := kafka.New(logger, kafkaData.Topic, kafkaData.Addr...)
 	if err != nil {
 		logger.Error("failed to create kafka", zap.Error(err))
 		return nil, err
 	}
+	kafka.SetTombstones(kafkaData.Tombstones)
 
 	endpoint := endpoint.New(reader, kafka, logger)
 
 	return &App{
 		endpoint: endpoint,
+		reader:   reader,
 		logger:   logger,
 	}, nil
 }
 
+func (a *App) ConfigureFeed(url string, config feed.Config) {
+	a.reader.SetFeedConfig(url, config)
+}
+
 func (a *App) Run(url, name, code string, delay time.Duration, ctx context.Context) error {
-	a.logger.Info("Starting app", zap.String("url", url), zap.String("name", name), zap.String("code", code), zap.Duration("delay", delay))
+	a.logger.Info("Starting app", zap.String("url", feed.RedactURL(url)), zap.String("name", name), zap.String("code", code), zap.Duration("delay", delay))
 	return a.endpoint.Run(url, name, code, delay, ctx)
 }
+
+func (a *App) Quarantined() []reader.QuarantinedFeed {
+	return a.reader.Quarantined()
+}
+
+func (a *App) Unquarantine(url string) bool {
+	return a.reader.Unquarantine(url)
+}
+
+func (a *App) Moved() map[string]string {
+	return a.reader.Moved()
+}